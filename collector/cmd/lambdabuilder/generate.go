@@ -0,0 +1,214 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"text/template"
+)
+
+const generatedHeader = "// Code generated by lambdabuilder. DO NOT EDIT.\n\n"
+
+var mainTemplate = template.Must(template.New("main.go").Parse(generatedHeader + `package main
+
+import (
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdaextension"
+)
+
+func main() {
+	lambdaextension.Run(components)
+}
+`))
+
+var componentsTemplate = template.Must(template.New("components.go").Parse(generatedHeader + `package main
+
+import (
+	"go.opentelemetry.io/collector/component"
+{{- range .Imports}}
+	{{.Name}} "{{.Import}}"
+{{- end}}
+)
+
+func components() (component.Factories, error) {
+	var err error
+	factories := component.Factories{}
+
+	factories.Extensions, err = component.MakeExtensionFactoryMap(
+{{- range .Extensions}}
+		{{.Name}}.NewFactory(),
+{{- end}}
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	factories.Receivers, err = component.MakeReceiverFactoryMap(
+{{- range .Receivers}}
+		{{.Name}}.NewFactory(),
+{{- end}}
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	factories.Exporters, err = component.MakeExporterFactoryMap(
+{{- range .Exporters}}
+		{{.Name}}.NewFactory(),
+{{- end}}
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	factories.Processors, err = component.MakeProcessorFactoryMap(
+{{- range .Processors}}
+		{{.Name}}.NewFactory(),
+{{- end}}
+	)
+	if err != nil {
+		return component.Factories{}, err
+	}
+
+	return factories, nil
+}
+`))
+
+var goModTemplate = template.Must(template.New("go.mod").Parse(`module {{.Dist.Module}}
+
+go 1.18
+
+require (
+{{- range .Requires}}
+	{{.}}
+{{- end}}
+)
+{{- range .Replaces}}
+
+replace {{.}}
+{{- end}}
+`))
+
+// lambdaNestedModules are the modules nested in the lambda module, relative to its path.
+var lambdaNestedModules = []string{"lambdalifecycle", "lambdacomponents"}
+
+type templateData struct {
+	*Manifest
+	Imports  []Module
+	Requires []string
+	Replaces []string
+}
+
+// generate writes the sources of the distribution to the output path of the manifest.
+func generate(m *Manifest) error {
+	data, err := newTemplateData(m)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(m.Dist.OutputPath, 0o755); err != nil {
+		return err
+	}
+	for _, f := range []struct {
+		tmpl   *template.Template
+		source bool
+	}{
+		{mainTemplate, true},
+		{componentsTemplate, true},
+		{goModTemplate, false},
+	} {
+		var buf bytes.Buffer
+		if err = f.tmpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("failed to generate %s: %w", f.tmpl.Name(), err)
+		}
+		out := buf.Bytes()
+		if f.source {
+			if out, err = format.Source(out); err != nil {
+				return fmt.Errorf("failed to format %s: %w", f.tmpl.Name(), err)
+			}
+		}
+		if err = os.WriteFile(filepath.Join(m.Dist.OutputPath, f.tmpl.Name()), out, 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newTemplateData(m *Manifest) (*templateData, error) {
+	data := &templateData{Manifest: m}
+	versions := make(map[string]string)
+	replaced := make(map[string]bool)
+	require := func(mod Module) error {
+		if version, ok := versions[mod.path()]; ok {
+			if version != mod.version() {
+				return fmt.Errorf("%s is required at both %s and %s", mod.path(), version, mod.version())
+			}
+			return nil
+		}
+		versions[mod.path()] = mod.version()
+		data.Requires = append(data.Requires, mod.GoMod)
+		if mod.Path != "" {
+			replaced[mod.path()] = true
+			data.Replaces = append(data.Replaces, fmt.Sprintf("%s => %s", mod.path(), mod.Path))
+		}
+		return nil
+	}
+
+	if err := require(m.Lambda); err != nil {
+		return nil, err
+	}
+	imported := make(map[string]bool)
+	for _, mod := range allModules(m) {
+		if err := require(mod); err != nil {
+			return nil, err
+		}
+		if !imported[mod.Import] {
+			imported[mod.Import] = true
+			data.Imports = append(data.Imports, mod)
+		}
+	}
+	// The lambda module requires its nested modules at v0.0.0 through replace directives of its own, which do not
+	// apply to the distribution, so a local checkout of the lambda module replaces them too.
+	if m.Lambda.Path != "" {
+		for _, nested := range lambdaNestedModules {
+			mod := Module{
+				GoMod: m.Lambda.path() + "/" + nested + " v0.0.0",
+				Path:  filepath.Join(m.Lambda.Path, nested),
+			}
+			if _, ok := versions[mod.path()]; ok {
+				if !replaced[mod.path()] {
+					data.Replaces = append(data.Replaces, fmt.Sprintf("%s => %s", mod.path(), mod.Path))
+				}
+				continue
+			}
+			if err := require(mod); err != nil {
+				return nil, err
+			}
+		}
+	}
+	data.Replaces = append(data.Replaces, m.Replaces...)
+	return data, nil
+}
+
+func allModules(m *Manifest) []Module {
+	var modules []Module
+	modules = append(modules, m.Extensions...)
+	modules = append(modules, m.Receivers...)
+	modules = append(modules, m.Exporters...)
+	modules = append(modules, m.Processors...)
+	return modules
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a distribution")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go is not installed")
+	}
+
+	m, err := loadManifest(filepath.Join("testdata", "build.yaml"))
+	require.NoError(t, err)
+	m.Lambda.Path, err = filepath.Abs(filepath.Join("..", ".."))
+	require.NoError(t, err)
+	m.Dist.OutputPath = t.TempDir()
+	require.NoError(t, generate(m))
+
+	cmd := exec.Command(goBin, "build", "-mod=mod", "-o", os.DevNull, ".")
+	cmd.Dir = m.Dist.OutputPath
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestGenerateGoMod(t *testing.T) {
+	m, err := loadManifest(filepath.Join("testdata", "manifest.yaml"))
+	require.NoError(t, err)
+	m.Dist.OutputPath = t.TempDir()
+	require.NoError(t, generate(m))
+
+	goMod, err := os.ReadFile(filepath.Join(m.Dist.OutputPath, "go.mod"))
+	require.NoError(t, err)
+	assert.Equal(t, `module example.com/otelcol-lambda
+
+go 1.18
+
+require (
+	github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/sigv4authextension v0.67.0
+	go.opentelemetry.io/collector v0.67.0
+	github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents v0.1.0
+	example.com/telemetry/exporter v0.3.0
+	github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle v0.0.0
+)
+
+replace github.com/open-telemetry/opentelemetry-lambda/collector => ../collector
+
+replace github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle => ../collector/lambdalifecycle
+
+replace github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents => ../collector/lambdacomponents
+
+replace example.com/telemetry/exporter => ../exporter
+`, string(goMod))
+}
+
+func TestManifestValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		manifest Manifest
+		err      string
+	}{
+		{
+			name:     "no module",
+			manifest: Manifest{Lambda: Module{GoMod: "github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0"}},
+			err:      "dist.module is required",
+		},
+		{
+			name:     "no lambda module",
+			manifest: Manifest{Dist: Distribution{Module: "example.com/dist"}},
+			err:      "lambda.gomod is required",
+		},
+		{
+			name: "no version",
+			manifest: Manifest{
+				Dist:      Distribution{Module: "example.com/dist"},
+				Lambda:    Module{GoMod: "github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0"},
+				Exporters: []Module{{GoMod: "example.com/exporter"}},
+			},
+			err: `exporters[0]: gomod must be a module path and a version, got "example.com/exporter"`,
+		},
+		{
+			name: "duplicate name",
+			manifest: Manifest{
+				Dist:      Distribution{Module: "example.com/dist"},
+				Lambda:    Module{GoMod: "github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0"},
+				Exporters: []Module{{GoMod: "example.com/a/otlpexporter v0.1.0"}, {GoMod: "example.com/b/otlpexporter v0.1.0"}},
+			},
+			err: `exporters[1]: "example.com/b/otlpexporter" is imported as otlpexporter too, set a different name`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.manifest.validate(), tc.err)
+		})
+	}
+
+	m := Manifest{
+		Dist:   Distribution{Module: "example.com/dist"},
+		Lambda: Module{GoMod: "github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0"},
+	}
+	require.NoError(t, m.validate())
+	assert.Equal(t, "collector", m.Dist.Name)
+	assert.Equal(t, "./_build", m.Dist.OutputPath)
+}
+
+func TestConflictingVersions(t *testing.T) {
+	m := &Manifest{
+		Dist:      Distribution{Module: "example.com/dist"},
+		Lambda:    Module{GoMod: "github.com/open-telemetry/opentelemetry-lambda/collector v0.1.0"},
+		Receivers: []Module{{GoMod: "go.opentelemetry.io/collector v0.67.0", Import: "go.opentelemetry.io/collector/receiver/otlpreceiver"}},
+		Exporters: []Module{{GoMod: "go.opentelemetry.io/collector v0.66.0", Import: "go.opentelemetry.io/collector/exporter/otlpexporter"}},
+	}
+	require.NoError(t, m.validate())
+	_, err := newTemplateData(m)
+	assert.EqualError(t, err, "go.opentelemetry.io/collector is required at both v0.67.0 and v0.66.0")
+}
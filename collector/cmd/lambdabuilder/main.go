@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command lambdabuilder generates the sources of a distribution of the Lambda extension from a manifest of the
+// OpenTelemetry Collector Builder: the components of the manifest are run by the lambdaextension package, so the
+// distribution keeps the Lambda specific behavior of the layer.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	config := flag.String("config", "", "path to the builder manifest")
+	outputPath := flag.String("output-path", "", "directory to write the sources to, overrides dist.output_path")
+	flag.Parse()
+
+	if *config == "" {
+		fmt.Fprintln(os.Stderr, "-config is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	m, err := loadManifest(*config)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *outputPath != "" {
+		m.Dist.OutputPath = *outputPath
+	}
+	if err = generate(m); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Sources of %s written to %s, build them with: go mod tidy && go build -o %s .\n", m.Dist.Module, m.Dist.OutputPath, m.Dist.Name)
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest is a manifest of the OpenTelemetry Collector Builder, with the lambda module added.
+// Settings of the builder that do not apply to a distribution of the extension are ignored.
+type Manifest struct {
+	Dist Distribution `yaml:"dist"`
+	// Lambda is the module of this repository providing the lambdaextension package.
+	Lambda     Module   `yaml:"lambda"`
+	Extensions []Module `yaml:"extensions"`
+	Exporters  []Module `yaml:"exporters"`
+	Processors []Module `yaml:"processors"`
+	Receivers  []Module `yaml:"receivers"`
+	Replaces   []string `yaml:"replaces"`
+}
+
+// Distribution describes the generated distribution.
+type Distribution struct {
+	Module     string `yaml:"module"`
+	Name       string `yaml:"name"`
+	OutputPath string `yaml:"output_path"`
+}
+
+// Module is a Go module providing a component.
+type Module struct {
+	// GoMod is the module path and version, e.g. "github.com/org/repo/exporter/myexporter v0.1.0".
+	GoMod string `yaml:"gomod"`
+	// Import is the package of the component, when it differs from the module path.
+	Import string `yaml:"import"`
+	// Name is the name the package is imported as.
+	Name string `yaml:"name"`
+	// Path is a local checkout of the module, which replaces it.
+	Path string `yaml:"path"`
+}
+
+func loadManifest(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err = yaml.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if err = m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", file, err)
+	}
+	return m, nil
+}
+
+// validate checks the manifest and fills in the defaults.
+func (m *Manifest) validate() error {
+	if m.Dist.Module == "" {
+		return errors.New("dist.module is required")
+	}
+	if m.Dist.Name == "" {
+		m.Dist.Name = "collector"
+	}
+	if m.Dist.OutputPath == "" {
+		m.Dist.OutputPath = "./_build"
+	}
+	if m.Lambda.GoMod == "" {
+		return errors.New("lambda.gomod is required")
+	}
+	if err := m.Lambda.validate(); err != nil {
+		return fmt.Errorf("lambda: %w", err)
+	}
+
+	names := make(map[string]string)
+	for kind, modules := range map[string][]Module{
+		"extensions": m.Extensions,
+		"exporters":  m.Exporters,
+		"processors": m.Processors,
+		"receivers":  m.Receivers,
+	} {
+		for i := range modules {
+			if err := modules[i].validate(); err != nil {
+				return fmt.Errorf("%s[%d]: %w", kind, i, err)
+			}
+			if other, ok := names[modules[i].Name]; ok && other != modules[i].Import {
+				return fmt.Errorf("%s[%d]: %q is imported as %s too, set a different name", kind, i, modules[i].Import, modules[i].Name)
+			}
+			names[modules[i].Name] = modules[i].Import
+		}
+	}
+	return nil
+}
+
+func (mod *Module) validate() error {
+	if len(strings.Fields(mod.GoMod)) != 2 {
+		return fmt.Errorf("gomod must be a module path and a version, got %q", mod.GoMod)
+	}
+	if mod.Import == "" {
+		mod.Import = mod.path()
+	}
+	if mod.Name == "" {
+		mod.Name = path.Base(mod.Import)
+	}
+	return nil
+}
+
+func (mod *Module) path() string {
+	return strings.Fields(mod.GoMod)[0]
+}
+
+func (mod *Module) version() string {
+	return strings.Fields(mod.GoMod)[1]
+}
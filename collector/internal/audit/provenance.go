@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit describes what an execution environment runs, for the audit trail of security and compliance teams.
+package audit // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/audit"
+
+import (
+	"context"
+	"sort"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
+	"go.uber.org/zap"
+)
+
+var (
+	versionKey  = tag.MustNewKey("version")
+	checksumKey = tag.MustNewKey("config_checksum")
+
+	statConfigInfo = stats.Int64("lambda_extension_config_info", "Always 1, tagged with the version of the extension and the checksum of its configuration", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views emitted by Provenance.Record.
+// They are exposed through the collector's own telemetry once registered with view.Register.
+func MetricViews() []*view.View {
+	return []*view.View{{
+		Name:        statConfigInfo.Name(),
+		Measure:     statConfigInfo,
+		Description: statConfigInfo.Description(),
+		TagKeys:     []tag.Key{versionKey, checksumKey},
+		Aggregation: view.LastValue(),
+	}}
+}
+
+// Provenance describes the collector started in the execution environment and where its configuration came from.
+type Provenance struct {
+	Version string
+	GitHash string
+	// ConfigURI is the URI the configuration was loaded from.
+	ConfigURI string
+	// ConfigChecksum is the SHA-256 checksum of the resolved configuration, after the expansion of the
+	// environment variables and the changes of the extension.
+	ConfigChecksum string
+	// Components are the IDs, prefixed with their kind, of the components used by the pipelines, and of the
+	// extensions enabled.
+	Components []string
+}
+
+// Components returns the IDs, prefixed with their kind and sorted, of the components used by cfg.
+func Components(cfg *service.Config) []string {
+	if cfg == nil {
+		return nil
+	}
+	ids := make(map[string]struct{})
+	add := func(kind string, list []component.ID) {
+		for _, id := range list {
+			ids[kind+"/"+id.String()] = struct{}{}
+		}
+	}
+	add("extension", cfg.Service.Extensions)
+	for _, p := range cfg.Service.Pipelines {
+		add("receiver", p.Receivers)
+		add("processor", p.Processors)
+		add("exporter", p.Exporters)
+	}
+	components := make([]string, 0, len(ids))
+	for id := range ids {
+		components = append(components, id)
+	}
+	sort.Strings(components)
+	return components
+}
+
+// Fields returns the provenance as log fields.
+func (p Provenance) Fields() []zap.Field {
+	return []zap.Field{
+		zap.String("version", p.Version),
+		zap.String("gitHash", p.GitHash),
+		zap.String("configURI", p.ConfigURI),
+		zap.String("configChecksum", p.ConfigChecksum),
+		zap.Strings("components", p.Components),
+	}
+}
+
+// Record writes the provenance to the lambda_extension_config_info metric.
+func (p Provenance) Record(ctx context.Context) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{
+		tag.Upsert(versionKey, p.Version),
+		tag.Upsert(checksumKey, p.ConfigChecksum),
+	}, statConfigInfo.M(1))
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/service"
+)
+
+func TestComponents(t *testing.T) {
+	cfg := &service.Config{Service: service.ConfigService{
+		Extensions: []component.ID{component.NewID("sigv4auth")},
+		Pipelines: map[component.ID]*service.ConfigServicePipeline{
+			component.NewID("traces"): {
+				Receivers: []component.ID{component.NewID("otlp")},
+				Exporters: []component.ID{component.NewIDWithName("otlphttp", "xray")},
+			},
+			component.NewID("metrics"): {
+				Receivers:  []component.ID{component.NewID("otlp")},
+				Processors: []component.ID{component.NewID("invocationbatch")},
+				Exporters:  []component.ID{component.NewID("awsemf")},
+			},
+		},
+	}}
+	assert.Equal(t, []string{
+		"exporter/awsemf",
+		"exporter/otlphttp/xray",
+		"extension/sigv4auth",
+		"processor/invocationbatch",
+		"receiver/otlp",
+	}, Components(cfg))
+	assert.Nil(t, Components(nil))
+}
+
+func TestRecord(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	Provenance{Version: "v0.1.0", ConfigChecksum: "abc"}.Record(context.Background())
+
+	rows, err := view.RetrieveData(statConfigInfo.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Len(t, rows[0].Tags, 2)
+	assert.Equal(t, 1.0, rows[0].Data.(*view.LastValueData).Value)
+}
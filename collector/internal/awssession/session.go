@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package awssession creates the AWS sessions the extension uses, outside of the components of the collector, to
+// load its configuration and secrets.
+package awssession // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/awssession"
+
+import (
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// EnvEndpointPrefix and EnvEndpointSuffix surround the upper-cased endpoint ID of a service, e.g. s3,
+// secretsmanager or sts, in the name of the environment variable overriding its endpoint, e.g.
+// OPENTELEMETRY_EXTENSION_S3_ENDPOINT=https://bucket.vpce-0123456789abcdef0-abcdefgh.s3.us-east-1.vpce.amazonaws.com,
+// for functions which can only reach AWS services through interface VPC endpoints.
+const (
+	EnvEndpointPrefix = "OPENTELEMETRY_EXTENSION_"
+	EnvEndpointSuffix = "_ENDPOINT"
+)
+
+// EnvConfigRoleARN is the environment variable holding the ARN of a role to assume to load the configuration, e.g.
+// from a bucket owned by another account. The function's role is used when it is not set.
+const EnvConfigRoleARN = "OPENTELEMETRY_EXTENSION_CONFIG_ROLE_ARN"
+
+// roleSessionName identifies the extension in the CloudTrail events of the account owning the role.
+const roleSessionName = "opentelemetry-lambda-extension"
+
+// New returns a session with the function's role, resolving the endpoints overridden in the environment.
+func New(cfgs ...*aws.Config) (*session.Session, error) {
+	base := &aws.Config{
+		EndpointResolver: endpoints.ResolverFunc(resolveEndpoint),
+		// Interface VPC endpoints are regional, the global STS endpoint can't be reached through them.
+		STSRegionalEndpoint: endpoints.RegionalSTSEndpoint,
+	}
+	return session.NewSession(append([]*aws.Config{base}, cfgs...)...)
+}
+
+func resolveEndpoint(service, region string, opts ...func(*endpoints.Options)) (endpoints.ResolvedEndpoint, error) {
+	if url := os.Getenv(EnvEndpointPrefix + strings.ToUpper(service) + EnvEndpointSuffix); url != "" {
+		return endpoints.ResolvedEndpoint{URL: url, SigningRegion: region}, nil
+	}
+	return endpoints.DefaultResolver().EndpointFor(service, region, opts...)
+}
+
+// NewForConfig returns a session for the providers loading the configuration, with the credentials of the role
+// set in EnvConfigRoleARN, if any, obtained with the function's role.
+func NewForConfig(cfgs ...*aws.Config) (*session.Session, error) {
+	sess, err := New(cfgs...)
+	if err != nil {
+		return nil, err
+	}
+	roleARN := os.Getenv(EnvConfigRoleARN)
+	if roleARN == "" {
+		return sess, nil
+	}
+	creds := stscreds.NewCredentials(sess, roleARN, func(p *stscreds.AssumeRoleProvider) {
+		p.RoleSessionName = roleSessionName
+	})
+	return sess.Copy(&aws.Config{Credentials: creds}), nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awssession
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEndpoint(t *testing.T) {
+	t.Setenv("OPENTELEMETRY_EXTENSION_SECRETSMANAGER_ENDPOINT", "https://vpce-0123456789abcdef0-abcdefgh.secretsmanager.eu-west-1.vpce.amazonaws.com")
+
+	resolved, err := resolveEndpoint("secretsmanager", "eu-west-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://vpce-0123456789abcdef0-abcdefgh.secretsmanager.eu-west-1.vpce.amazonaws.com", resolved.URL)
+	assert.Equal(t, "eu-west-1", resolved.SigningRegion)
+
+	resolved, err = resolveEndpoint("s3", "eu-west-1")
+	require.NoError(t, err)
+	assert.Equal(t, "https://s3.eu-west-1.amazonaws.com", resolved.URL)
+}
+
+func TestNewUsesRegionalSTSEndpoint(t *testing.T) {
+	sess, err := New()
+	require.NoError(t, err)
+	assert.Equal(t, endpoints.RegionalSTSEndpoint, sess.Config.STSRegionalEndpoint)
+
+	resolved, err := sess.Config.EndpointResolver.EndpointFor("sts", "eu-west-1", func(o *endpoints.Options) {
+		o.STSRegionalEndpoint = endpoints.RegionalSTSEndpoint
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://sts.eu-west-1.amazonaws.com", resolved.URL)
+}
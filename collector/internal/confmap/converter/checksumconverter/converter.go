@@ -0,0 +1,45 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksumconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/checksumconverter"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+type converter struct {
+	record func(checksum string)
+}
+
+// New returns a confmap.Converter, that passes the SHA-256 checksum of the configuration to record, without
+// changing it. Placed after the other converters, it identifies the configuration the collector runs.
+func New(record func(checksum string)) confmap.Converter {
+	return &converter{record: record}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	// Maps are marshaled with sorted keys, so the checksum doesn't depend on the order of the configuration.
+	content, err := json.Marshal(conf.ToStringMap())
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(content)
+	c.record(hex.EncodeToString(sum[:]))
+	return nil
+}
@@ -0,0 +1,39 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checksumconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	checksum := func(conf map[string]any) string {
+		var sum string
+		c := confmap.NewFromStringMap(conf)
+		require.NoError(t, New(func(s string) { sum = s }).Convert(context.Background(), c))
+		assert.Equal(t, conf, c.ToStringMap())
+		return sum
+	}
+
+	otlp := checksum(map[string]any{"exporters": map[string]any{"otlp": map[string]any{"endpoint": "backend:4317"}, "logging": nil}})
+	assert.Len(t, otlp, 64)
+	assert.Equal(t, otlp, checksum(map[string]any{"exporters": map[string]any{"logging": nil, "otlp": map[string]any{"endpoint": "backend:4317"}}}))
+	assert.NotEqual(t, otlp, checksum(map[string]any{"exporters": map[string]any{"otlp": map[string]any{"endpoint": "attacker:4317"}, "logging": nil}}))
+}
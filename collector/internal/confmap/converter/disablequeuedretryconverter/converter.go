@@ -22,7 +22,14 @@ import (
 )
 
 const (
-	expKey = "exporters"
+	// EnvSyncExport is the environment variable that, when set to true, makes all telemetry be exported during
+	// the invocation that produced it.
+	EnvSyncExport = "OPENTELEMETRY_EXTENSION_SYNC_EXPORT"
+
+	expKey  = "exporters"
+	procKey = "processors"
+
+	invocationBatchProcessor = "invocationbatch"
 )
 
 var exporters = map[string]struct{}{
@@ -55,11 +62,14 @@ var exporters = map[string]struct{}{
 }
 
 type converter struct {
+	syncExport bool
 }
 
-// New returns a confmap.Converter, that ensures queued retry is disabled for all configured exporters.
-func New() confmap.Converter {
-	return &converter{}
+// New returns a confmap.Converter, that ensures queued retry is disabled for all configured exporters,
+// except the ones whose queue is persisted by a storage extension. With syncExport, persisted queues are
+// disabled too, and invocationbatch processors pass telemetry through instead of holding it across invocations.
+func New(syncExport bool) confmap.Converter {
+	return &converter{syncExport: syncExport}
 }
 
 func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
@@ -72,9 +82,19 @@ func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
 			if _, ok := exporters[strings.Split(name, "/")[0]]; !ok {
 				continue
 			}
+			if !c.syncExport && conf.IsSet(fmt.Sprintf("%s::%s::sending_queue::storage", expKey, name)) {
+				continue
+			}
 			out[fmt.Sprintf("%s::%s::sending_queue::enabled", expKey, name)] = false
 		}
 	}
+	if procs, ok := conf.Get(procKey).(map[string]interface{}); ok && c.syncExport {
+		for name := range procs {
+			if strings.Split(name, "/")[0] == invocationBatchProcessor {
+				out[fmt.Sprintf("%s::%s::passthrough", procKey, name)] = true
+			}
+		}
+	}
 	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
 		return err
 	}
@@ -23,10 +23,11 @@ import (
 
 func TestConvert(t *testing.T) {
 	for _, tc := range []struct {
-		name     string
-		conf     *confmap.Conf
-		expected *confmap.Conf
-		err      error
+		name       string
+		conf       *confmap.Conf
+		syncExport bool
+		expected   *confmap.Conf
+		err        error
 	}{
 		{
 			name:     "no exporters",
@@ -52,9 +53,28 @@ func TestConvert(t *testing.T) {
 			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"enabled": false}}, "otlp": map[string]any{"sending_queue": map[string]any{"enabled": false}}}}),
 			err:      nil,
 		},
+		{
+			name:     "persistent queue",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"storage": "tmp_storage"}}, "otlp": map[string]any{}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"storage": "tmp_storage"}}, "otlp": map[string]any{"sending_queue": map[string]any{"enabled": false}}}}),
+			err:      nil,
+		},
+		{
+			name:       "sync export",
+			conf:       confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"storage": "tmp_storage"}}}, "processors": map[string]any{"invocationbatch/traces": map[string]any{}, "batch": map[string]any{}}}),
+			syncExport: true,
+			expected:   confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlphttp": map[string]any{"sending_queue": map[string]any{"storage": "tmp_storage", "enabled": false}}}, "processors": map[string]any{"invocationbatch/traces": map[string]any{"passthrough": true}, "batch": map[string]any{}}}),
+			err:        nil,
+		},
+		{
+			name:     "invocationbatch without sync export",
+			conf:     confmap.NewFromStringMap(map[string]any{"processors": map[string]any{"invocationbatch": map[string]any{}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"processors": map[string]any{"invocationbatch": map[string]any{}}}),
+			err:      nil,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			c := New()
+			c := New(tc.syncExport)
 			err := c.Convert(context.Background(), tc.conf)
 			assert.Equal(t, err, tc.err)
 			assert.Equal(t, tc.conf, tc.expected)
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterdefaultsconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/exporterdefaultsconverter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	expKey = "exporters"
+)
+
+// queueDefaults are the sending queue settings of exporters whose queue is enabled, which is only the case when
+// it is persisted by a storage extension. A single consumer matches the CPU share of most functions, and few
+// batches are pending between two flushes.
+var queueDefaults = map[string]interface{}{
+	"num_consumers": 1,
+	"queue_size":    100,
+}
+
+// retryDefaults bound the time an export is retried for, which the environment is kept busy for when the
+// sending queue is disabled, to seconds rather than the five minutes of the upstream defaults.
+var retryDefaults = map[string]interface{}{
+	"initial_interval": "1s",
+	"max_interval":     "5s",
+	"max_elapsed_time": "10s",
+}
+
+// Exporters configured with exporterhelper.QueueSettings and exporterhelper.RetrySettings.
+var queuedExporters = map[string]struct{}{
+	"datadog":     {},
+	"googlecloud": {},
+	"kafka":       {},
+	"loki":        {},
+	"otlp":        {},
+	"otlphttp":    {},
+	"splunkhec":   {},
+}
+
+// Exporters only configured with exporterhelper.RetrySettings.
+var retriedExporters = map[string]struct{}{
+	"prometheusremotewrite": {},
+}
+
+type converter struct {
+}
+
+// New returns a confmap.Converter, that sets the sending queue and retry settings of the configured exporters
+// to values sized for an execution environment rather than a long-lived collector, unless they are set.
+func New() confmap.Converter {
+	return &converter{}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	out := make(map[string]interface{})
+	expVal := conf.Get(expKey)
+
+	switch exps := expVal.(type) {
+	case map[string]interface{}:
+		for name := range exps {
+			typ := strings.Split(name, "/")[0]
+			_, queued := queuedExporters[typ]
+			_, retried := retriedExporters[typ]
+			if queued {
+				setDefaults(conf, out, fmt.Sprintf("%s::%s::sending_queue", expKey, name), queueDefaults)
+			}
+			if queued || retried {
+				setDefaults(conf, out, fmt.Sprintf("%s::%s::retry_on_failure", expKey, name), retryDefaults)
+			}
+		}
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// setDefaults adds to out the defaults under prefix that are not set in conf.
+func setDefaults(conf *confmap.Conf, out map[string]interface{}, prefix string, defaults map[string]interface{}) {
+	for key, value := range defaults {
+		key = fmt.Sprintf("%s::%s", prefix, key)
+		if !conf.IsSet(key) {
+			out[key] = value
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exporterdefaultsconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	retry := map[string]any{"initial_interval": "1s", "max_interval": "5s", "max_elapsed_time": "10s"}
+	for _, tc := range []struct {
+		name     string
+		conf     *confmap.Conf
+		expected *confmap.Conf
+		err      error
+	}{
+		{
+			name:     "no exporters",
+			conf:     confmap.New(),
+			expected: confmap.New(),
+			err:      nil,
+		},
+		{
+			name:     "other exporter",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"logging": nil}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"logging": nil}}),
+			err:      nil,
+		},
+		{
+			name: "defaults",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp/mine":             nil,
+				"prometheusremotewrite": map[string]any{"endpoint": "https://backend/api/v1/write"},
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp/mine": map[string]any{
+					"sending_queue":    map[string]any{"num_consumers": 1, "queue_size": 100},
+					"retry_on_failure": retry,
+				},
+				"prometheusremotewrite": map[string]any{
+					"endpoint":         "https://backend/api/v1/write",
+					"retry_on_failure": retry,
+				},
+			}}),
+			err: nil,
+		},
+		{
+			name: "configured settings",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlphttp": map[string]any{
+					"sending_queue":    map[string]any{"queue_size": 5000},
+					"retry_on_failure": map[string]any{"max_elapsed_time": "1m"},
+				},
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlphttp": map[string]any{
+					"sending_queue":    map[string]any{"num_consumers": 1, "queue_size": 5000},
+					"retry_on_failure": map[string]any{"initial_interval": "1s", "max_interval": "5s", "max_elapsed_time": "1m"},
+				},
+			}}),
+			err: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New()
+			err := c.Convert(context.Background(), tc.conf)
+			assert.Equal(t, err, tc.err)
+			assert.Equal(t, tc.conf, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerotationconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/filerotationconverter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	expKey  = "exporters"
+	expType = "file"
+
+	// Lambda's /tmp is 512 MB by default: keep the current file and its backups well below that.
+	maxMegabytes = 64
+	maxBackups   = 2
+)
+
+type converter struct {
+}
+
+// New returns a confmap.Converter, that enables size-capped rotation for file exporters configured without rotation,
+// so that dumping telemetry cannot fill up the ephemeral storage of the execution environment.
+func New() confmap.Converter {
+	return &converter{}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	out := make(map[string]interface{})
+	expVal := conf.Get(expKey)
+
+	switch exps := expVal.(type) {
+	case map[string]interface{}:
+		for name := range exps {
+			if strings.Split(name, "/")[0] != expType {
+				continue
+			}
+			rotationKey := fmt.Sprintf("%s::%s::rotation", expKey, name)
+			if conf.IsSet(rotationKey) {
+				continue
+			}
+			out[rotationKey+"::max_megabytes"] = maxMegabytes
+			out[rotationKey+"::max_backups"] = maxBackups
+		}
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filerotationconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		conf     *confmap.Conf
+		expected *confmap.Conf
+		err      error
+	}{
+		{
+			name:     "no exporters",
+			conf:     confmap.New(),
+			expected: confmap.New(),
+			err:      nil,
+		},
+		{
+			name:     "no file exporters",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": map[string]any{}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": map[string]any{}}}),
+			err:      nil,
+		},
+		{
+			name:     "file exporters without rotation",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"file": map[string]any{"path": "/tmp/a.json"}, "file/b": map[string]any{"path": "/tmp/b.json"}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"file": map[string]any{"path": "/tmp/a.json", "rotation": map[string]any{"max_megabytes": 64, "max_backups": 2}}, "file/b": map[string]any{"path": "/tmp/b.json", "rotation": map[string]any{"max_megabytes": 64, "max_backups": 2}}}}),
+			err:      nil,
+		},
+		{
+			name:     "file exporter with rotation",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"file": map[string]any{"path": "/tmp/a.json", "rotation": map[string]any{"max_megabytes": 10}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"file": map[string]any{"path": "/tmp/a.json", "rotation": map[string]any{"max_megabytes": 10}}}}),
+			err:      nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New()
+			err := c.Convert(context.Background(), tc.conf)
+			assert.Equal(t, err, tc.err)
+			assert.Equal(t, tc.conf, tc.expected)
+		})
+	}
+}
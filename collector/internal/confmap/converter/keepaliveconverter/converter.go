@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keepaliveconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/keepaliveconverter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	expKey = "exporters"
+
+	// idleConnTimeout closes idle HTTP connections before the load balancers and NAT gateways in front of
+	// most backends drop them, so that a connection is not reused after it died while the environment was frozen.
+	idleConnTimeout = "30s"
+	// keepaliveTime is how long a gRPC connection stays idle before its keepalive goes dormant: the next
+	// export then starts with a ping, which detects a connection that died while the environment was
+	// frozen within keepaliveTimeout rather than after a full export timeout.
+	keepaliveTime    = "30s"
+	keepaliveTimeout = "5s"
+)
+
+// Exporters whose client is configured with confighttp.HTTPClientSettings.
+var httpExporters = map[string]struct{}{
+	"loki":                  {},
+	"otlphttp":              {},
+	"prometheusremotewrite": {},
+}
+
+// Exporters whose client is configured with configgrpc.GRPCClientSettings.
+var grpcExporters = map[string]struct{}{
+	"otlp": {},
+}
+
+type converter struct {
+}
+
+// New returns a confmap.Converter, that tunes the connections of the configured exporters for the freeze and
+// thaw of the execution environment, unless their connection settings are already configured.
+func New() confmap.Converter {
+	return &converter{}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	out := make(map[string]interface{})
+	expVal := conf.Get(expKey)
+
+	switch exps := expVal.(type) {
+	case map[string]interface{}:
+		for name := range exps {
+			typ := strings.Split(name, "/")[0]
+			if _, ok := httpExporters[typ]; ok {
+				key := fmt.Sprintf("%s::%s::idle_conn_timeout", expKey, name)
+				if !conf.IsSet(key) {
+					out[key] = idleConnTimeout
+				}
+			}
+			if _, ok := grpcExporters[typ]; ok {
+				key := fmt.Sprintf("%s::%s::keepalive", expKey, name)
+				if !conf.IsSet(key) {
+					out[key+"::time"] = keepaliveTime
+					out[key+"::timeout"] = keepaliveTimeout
+					out[key+"::permit_without_stream"] = false
+				}
+			}
+		}
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
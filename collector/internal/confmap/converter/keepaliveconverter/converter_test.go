@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keepaliveconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		conf     *confmap.Conf
+		expected *confmap.Conf
+		err      error
+	}{
+		{
+			name:     "no exporters",
+			conf:     confmap.New(),
+			expected: confmap.New(),
+			err:      nil,
+		},
+		{
+			name:     "other exporter",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"logging": nil}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"logging": nil}}),
+			err:      nil,
+		},
+		{
+			name: "otlp exporters",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp":          map[string]any{"endpoint": "backend:4317"},
+				"otlphttp/mine": map[string]any{"endpoint": "https://backend:4318"},
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp": map[string]any{
+					"endpoint":  "backend:4317",
+					"keepalive": map[string]any{"time": "30s", "timeout": "5s", "permit_without_stream": false},
+				},
+				"otlphttp/mine": map[string]any{"endpoint": "https://backend:4318", "idle_conn_timeout": "30s"},
+			}}),
+			err: nil,
+		},
+		{
+			name: "configured connections",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp":                  map[string]any{"keepalive": map[string]any{"time": "1m"}},
+				"prometheusremotewrite": map[string]any{"idle_conn_timeout": "90s"},
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp":                  map[string]any{"keepalive": map[string]any{"time": "1m"}},
+				"prometheusremotewrite": map[string]any{"idle_conn_timeout": "90s"},
+			}}),
+			err: nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New()
+			err := c.Convert(context.Background(), tc.conf)
+			assert.Equal(t, err, tc.err)
+			assert.Equal(t, tc.conf, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/proxyconverter"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/proxy"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const expKey = "exporters"
+
+// Exporters whose client is configured with awsutil.AWSSessionSettings, by the endpoint prefix of their service.
+// Unlike the other HTTP and gRPC clients, it only reads HTTPS_PROXY and ignores NO_PROXY, so an endpoint exempt
+// from the proxy can't be reached directly while HTTPS_PROXY is set.
+var awsExporters = map[string]string{
+	"awsemf":  "logs",
+	"awsxray": "xray",
+}
+
+type converter struct {
+}
+
+// New returns a confmap.Converter, that sets the proxy_address of the AWS exporters to the proxy the
+// environment selects for their endpoint, unless it is already configured. It fails when their endpoint is
+// exempt from HTTPS_PROXY, which the exporters would proxy regardless.
+func New() confmap.Converter {
+	return &converter{}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	out := make(map[string]interface{})
+	exps, _ := conf.Get(expKey).(map[string]interface{})
+	for name := range exps {
+		service, ok := awsExporters[strings.Split(name, "/")[0]]
+		if !ok {
+			continue
+		}
+		key := fmt.Sprintf("%s::%s::proxy_address", expKey, name)
+		if conf.IsSet(key) {
+			continue
+		}
+		endpoint, _ := conf.Get(fmt.Sprintf("%s::%s::endpoint", expKey, name)).(string)
+		if endpoint == "" {
+			region, _ := conf.Get(fmt.Sprintf("%s::%s::region", expKey, name)).(string)
+			if region == "" {
+				region = os.Getenv("AWS_REGION")
+			}
+			endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", service, region)
+		}
+		address, err := proxy.For(endpoint)
+		if err != nil {
+			return fmt.Errorf("cannot select the proxy of %s: %w", name, err)
+		}
+		if address == "" {
+			// An empty proxy_address falls back to HTTPS_PROXY, which would proxy the endpoint anyway.
+			if os.Getenv("HTTPS_PROXY") != "" {
+				return fmt.Errorf("the endpoint %s of %s is exempt from the proxy by NO_PROXY, which the exporter ignores "+
+					"while HTTPS_PROXY is set: use https_proxy instead, or set its proxy_address", endpoint, name)
+			}
+			continue
+		}
+		out[key] = address
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxyconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "http://proxy.corp:3128")
+	t.Setenv("NO_PROXY", "vpce.amazonaws.com")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	for _, tc := range []struct {
+		name     string
+		conf     *confmap.Conf
+		expected *confmap.Conf
+	}{
+		{
+			name:     "no exporters",
+			conf:     confmap.New(),
+			expected: confmap.New(),
+		},
+		{
+			name:     "other exporter",
+			conf:     confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": nil}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": nil}}),
+		},
+		{
+			name: "aws exporters",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"awsxray":      nil,
+				"awsemf":       map[string]any{"region": "us-east-1"},
+				"awsemf/vpce":  map[string]any{"endpoint": "https://vpce-0123456789abcdef0-abcdefgh.logs.eu-west-1.vpce.amazonaws.com"},
+				"awsxray/mine": map[string]any{"proxy_address": "http://other.corp:3128"},
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"awsxray":      map[string]any{"proxy_address": "http://proxy.corp:3128"},
+				"awsemf":       map[string]any{"region": "us-east-1", "proxy_address": "http://proxy.corp:3128"},
+				"awsemf/vpce":  map[string]any{"endpoint": "https://vpce-0123456789abcdef0-abcdefgh.logs.eu-west-1.vpce.amazonaws.com"},
+				"awsxray/mine": map[string]any{"proxy_address": "http://other.corp:3128"},
+			}}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New()
+			err := c.Convert(context.Background(), tc.conf)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, tc.conf)
+		})
+	}
+}
+
+func TestConvertExemptFromHTTPSProxy(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "http://proxy.corp:3128")
+	t.Setenv("NO_PROXY", "vpce.amazonaws.com")
+	t.Setenv("AWS_REGION", "eu-west-1")
+
+	conf := confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"awsxray": nil}})
+	assert.NoError(t, New().Convert(context.Background(), conf))
+	assert.Equal(t, "http://proxy.corp:3128", conf.Get("exporters::awsxray::proxy_address"))
+
+	conf = confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+		"awsemf/vpce": map[string]any{"endpoint": "https://vpce-0123456789abcdef0-abcdefgh.logs.eu-west-1.vpce.amazonaws.com"},
+	}})
+	assert.EqualError(t, New().Convert(context.Background(), conf), "the endpoint "+
+		"https://vpce-0123456789abcdef0-abcdefgh.logs.eu-west-1.vpce.amazonaws.com of awsemf/vpce is exempt from the "+
+		"proxy by NO_PROXY, which the exporter ignores while HTTPS_PROXY is set: use https_proxy instead, or set its "+
+		"proxy_address")
+}
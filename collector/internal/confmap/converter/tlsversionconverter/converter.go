@@ -0,0 +1,119 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsversionconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/tlsversionconverter"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// EnvMinVersion is the environment variable holding the minimum TLS version, e.g. 1.2, of the servers of the
+	// receivers and the clients of the exporters and extensions.
+	EnvMinVersion = "OPENTELEMETRY_EXTENSION_TLS_MIN_VERSION"
+
+	tlsKey        = "tls"
+	minVersionKey = "min_version"
+)
+
+// versions are the values of configtls' min_version, from the oldest to the newest.
+var versions = []string{"1.0", "1.1", "1.2", "1.3"}
+
+// Exporters configured with configtls.TLSClientSetting under tls, even when it is not set. Elsewhere, e.g. under
+// the auth of the kafka exporter or the protocols of a receiver, a tls key left empty turns TLS off.
+var tlsExporters = map[string]struct{}{
+	"loki":                  {},
+	"otlp":                  {},
+	"otlphttp":              {},
+	"prometheusremotewrite": {},
+	"splunkhec":             {},
+}
+
+type converter struct {
+	minVersion string
+}
+
+// New returns a confmap.Converter, that raises the min_version of every TLS setting of the receivers, exporters and
+// extensions to minVersion, without turning TLS on where it isn't. It does nothing if minVersion is empty.
+func New(minVersion string) confmap.Converter {
+	return &converter{minVersion: minVersion}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if c.minVersion == "" {
+		return nil
+	}
+	minRank := rank(c.minVersion)
+	if minRank < 0 {
+		return fmt.Errorf("invalid %s %q, expected one of %s", EnvMinVersion, c.minVersion, strings.Join(versions, ", "))
+	}
+
+	out := make(map[string]interface{})
+	for _, kind := range []string{"receivers", "exporters", "extensions"} {
+		components, _ := conf.Get(kind).(map[string]interface{})
+		for name, cfg := range components {
+			key := fmt.Sprintf("%s::%s", kind, name)
+			typ := strings.Split(name, "/")[0]
+			if _, ok := tlsExporters[typ]; ok && kind == "exporters" && conf.Get(fmt.Sprintf("%s::%s", key, tlsKey)) == nil {
+				out[fmt.Sprintf("%s::%s::%s", key, tlsKey, minVersionKey)] = c.minVersion
+			}
+			for _, tls := range findTLS(key, cfg) {
+				versionKey := fmt.Sprintf("%s::%s", tls, minVersionKey)
+				current, _ := conf.Get(versionKey).(string)
+				// An unknown version is left for the component to reject.
+				if current == "" || (rank(current) >= 0 && rank(current) < minRank) {
+					out[versionKey] = c.minVersion
+				}
+			}
+		}
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// findTLS returns the keys of the TLS settings found in cfg, at any depth, e.g. under the protocols of a receiver.
+// Empty settings are left out.
+func findTLS(key string, cfg interface{}) []string {
+	m, ok := cfg.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	var keys []string
+	for k, v := range m {
+		child := fmt.Sprintf("%s::%s", key, k)
+		if k == tlsKey {
+			if tls, ok := v.(map[string]interface{}); ok && tls != nil {
+				keys = append(keys, child)
+			}
+			continue
+		}
+		keys = append(keys, findTLS(child, v)...)
+	}
+	return keys
+}
+
+func rank(version string) int {
+	for i, v := range versions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
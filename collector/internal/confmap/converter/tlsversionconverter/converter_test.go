@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsversionconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		minVersion string
+		conf       *confmap.Conf
+		expected   *confmap.Conf
+		err        bool
+	}{
+		{
+			name:       "no policy",
+			minVersion: "",
+			conf:       confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": nil}}),
+			expected:   confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{"otlp": nil}}),
+		},
+		{
+			name:       "invalid policy",
+			minVersion: "TLS12",
+			conf:       confmap.New(),
+			expected:   confmap.New(),
+			err:        true,
+		},
+		{
+			name:       "exporters",
+			minVersion: "1.3",
+			conf: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp":          map[string]any{"endpoint": "backend:4317"},
+				"otlp/empty":    map[string]any{"tls": nil},
+				"otlphttp/mine": map[string]any{"tls": map[string]any{"min_version": "1.2", "ca_file": "/opt/ca.pem"}},
+				"kafka":         map[string]any{"auth": map[string]any{"tls": nil}},
+				"kafka/tls":     map[string]any{"auth": map[string]any{"tls": map[string]any{"ca_file": "/opt/ca.pem"}}},
+				"logging":       nil,
+			}}),
+			expected: confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{
+				"otlp":          map[string]any{"endpoint": "backend:4317", "tls": map[string]any{"min_version": "1.3"}},
+				"otlp/empty":    map[string]any{"tls": map[string]any{"min_version": "1.3"}},
+				"otlphttp/mine": map[string]any{"tls": map[string]any{"min_version": "1.3", "ca_file": "/opt/ca.pem"}},
+				"kafka":         map[string]any{"auth": map[string]any{"tls": nil}},
+				"kafka/tls":     map[string]any{"auth": map[string]any{"tls": map[string]any{"ca_file": "/opt/ca.pem", "min_version": "1.3"}}},
+				"logging":       nil,
+			}}),
+		},
+		{
+			name:       "receivers",
+			minVersion: "1.2",
+			conf: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{
+				"grpc": map[string]any{"tls": map[string]any{"cert_file": "/tmp/server.crt", "min_version": "1.3"}},
+				"http": map[string]any{"tls": map[string]any{"cert_file": "/tmp/server.crt", "min_version": "1.1"}},
+			}}, "otlp/plain": map[string]any{"protocols": map[string]any{
+				"grpc": map[string]any{"tls": nil},
+			}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{
+				"grpc": map[string]any{"tls": map[string]any{"cert_file": "/tmp/server.crt", "min_version": "1.3"}},
+				"http": map[string]any{"tls": map[string]any{"cert_file": "/tmp/server.crt", "min_version": "1.2"}},
+			}}, "otlp/plain": map[string]any{"protocols": map[string]any{
+				"grpc": map[string]any{"tls": nil},
+			}}}}),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(tc.minVersion)
+			err := c.Convert(context.Background(), tc.conf)
+			if tc.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, tc.conf)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unixsocketconverter // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/unixsocketconverter"
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const (
+	// EnvSocket is the environment variable holding the path of the socket. It is set in the function's
+	// configuration, so that both the function and the extension know it.
+	EnvSocket = "OPENTELEMETRY_COLLECTOR_OTLP_SOCKET"
+
+	rcvKey   = "receivers"
+	otlpType = "otlp"
+)
+
+type converter struct {
+	path string
+}
+
+// New returns a confmap.Converter, that moves the gRPC server of the otlp receiver to the unix socket at path,
+// so that the function can export without going through the TCP stack. Receivers without a gRPC server are left
+// as they are, and only one receiver may have one. It does nothing if path is empty.
+func New(path string) confmap.Converter {
+	return &converter{path: path}
+}
+
+func (c converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if c.path == "" {
+		return nil
+	}
+	rcvs, _ := conf.Get(rcvKey).(map[string]interface{})
+	names := make([]string, 0, len(rcvs))
+	for name := range rcvs {
+		if name == otlpType || strings.HasPrefix(name, otlpType+"/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	out := make(map[string]interface{})
+	moved := ""
+	for _, name := range names {
+		protocols, _ := conf.Get(fmt.Sprintf("%s::%s::protocols", rcvKey, name)).(map[string]interface{})
+		if _, ok := protocols["grpc"]; !ok {
+			continue
+		}
+		if moved != "" {
+			return fmt.Errorf("both %s and %s have a gRPC server, only one of them can listen on %s", moved, name, c.path)
+		}
+		moved = name
+		grpcKey := fmt.Sprintf("%s::%s::protocols::grpc", rcvKey, name)
+		out[grpcKey+"::endpoint"] = c.path
+		out[grpcKey+"::transport"] = "unix"
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(out)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package unixsocketconverter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+func TestConvert(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		path     string
+		conf     *confmap.Conf
+		expected *confmap.Conf
+		err      error
+	}{
+		{
+			name:     "no socket",
+			conf:     confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"grpc": nil}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"grpc": nil}}}}),
+			err:      nil,
+		},
+		{
+			name:     "no otlp receiver",
+			path:     "/tmp/otlp.sock",
+			conf:     confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"zipkin": map[string]any{}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"zipkin": map[string]any{}}}),
+			err:      nil,
+		},
+		{
+			name:     "http only",
+			path:     "/tmp/otlp.sock",
+			conf:     confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"http": nil}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"http": nil}}}}),
+			err:      nil,
+		},
+		{
+			name:     "named otlp receiver",
+			path:     "/tmp/otlp.sock",
+			conf:     confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp/function": map[string]any{"protocols": map[string]any{"grpc": nil}}, "otlp": map[string]any{"protocols": map[string]any{"http": nil}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp/function": map[string]any{"protocols": map[string]any{"grpc": map[string]any{"endpoint": "/tmp/otlp.sock", "transport": "unix"}}}, "otlp": map[string]any{"protocols": map[string]any{"http": nil}}}}),
+			err:      nil,
+		},
+		{
+			name:     "otlp receiver",
+			path:     "/tmp/otlp.sock",
+			conf:     confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{"endpoint": "localhost:4317"}, "http": nil}}}}),
+			expected: confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"otlp": map[string]any{"protocols": map[string]any{"grpc": map[string]any{"endpoint": "/tmp/otlp.sock", "transport": "unix"}, "http": nil}}}}),
+			err:      nil,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(tc.path)
+			err := c.Convert(context.Background(), tc.conf)
+			assert.Equal(t, err, tc.err)
+			assert.Equal(t, tc.conf, tc.expected)
+		})
+	}
+}
+
+func TestConvertSeveralGRPCServers(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{
+		"otlp":          map[string]any{"protocols": map[string]any{"grpc": nil}},
+		"otlp/function": map[string]any{"protocols": map[string]any{"grpc": nil}},
+	}})
+	err := New("/tmp/otlp.sock").Convert(context.Background(), conf)
+	assert.EqualError(t, err, "both otlp and otlp/function have a gRPC server, only one of them can listen on /tmp/otlp.sock")
+}
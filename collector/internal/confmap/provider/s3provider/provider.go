@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package s3provider loads the configuration from an object in Amazon S3, accepting the URIs of the s3provider
+// of the collector contrib repository, with the credentials of the awssession package.
+package s3provider // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/provider/s3provider"
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/awssession"
+	"go.opentelemetry.io/collector/confmap"
+	"gopkg.in/yaml.v3"
+)
+
+const schemeName = "s3"
+
+// EnvChecksum is the environment variable holding the SHA-256 checksum of the configuration, either as a hex
+// digest or as the URI of an object holding it, e.g. written by sha256sum, preferably in a bucket the owners of
+// the configuration bucket can't write to. The configuration is rejected when it doesn't match.
+const EnvChecksum = "OPENTELEMETRY_EXTENSION_CONFIG_SHA256"
+
+// getter returns the content of an object.
+type getter func(ctx context.Context, region, bucket, key string) (io.ReadCloser, error)
+
+type provider struct {
+	get       getter
+	configURI string
+	checksum  string
+}
+
+// New returns a provider for URIs in the s3://<bucket>.s3.<region>.amazonaws.com/<key> format, verifying that
+// the object at configURI matches checksum, in the format of EnvChecksum, unless it is empty. The objects
+// referenced in the values of the configuration, e.g. "${s3:...}", are not verified.
+func New(configURI, checksum string) confmap.Provider {
+	return &provider{get: getObject, configURI: configURI, checksum: checksum}
+}
+
+func getObject(ctx context.Context, region, bucket, key string) (io.ReadCloser, error) {
+	sess, err := awssession.NewForConfig(&aws.Config{Region: aws.String(region)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an AWS session: %w", err)
+	}
+	out, err := s3.New(sess).GetObjectWithContext(ctx, &s3.GetObjectInput{Bucket: aws.String(bucket), Key: aws.String(key)})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+func (p *provider) Retrieve(ctx context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+	content, err := p.read(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	if uri == p.configURI {
+		if err = p.verify(ctx, uri, content); err != nil {
+			return nil, err
+		}
+	}
+
+	var conf map[string]interface{}
+	if err = yaml.Unmarshal(content, &conf); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", uri, err)
+	}
+	return confmap.NewRetrieved(conf)
+}
+
+func (p *provider) read(ctx context.Context, uri string) ([]byte, error) {
+	region, bucket, key, err := splitURI(uri)
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.get(ctx, region, bucket, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", uri, err)
+	}
+	defer body.Close()
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", uri, err)
+	}
+	return content, nil
+}
+
+// verify checks that content, read from uri, matches the checksum of the provider.
+func (p *provider) verify(ctx context.Context, uri string, content []byte) error {
+	if p.checksum == "" {
+		return nil
+	}
+	expected := p.checksum
+	if strings.HasPrefix(expected, schemeName+":") {
+		sidecar, err := p.read(ctx, expected)
+		if err != nil {
+			return fmt.Errorf("failed to get the checksum of %s: %w", uri, err)
+		}
+		// The digest is followed by the file name in the output of sha256sum.
+		fields := strings.Fields(string(sidecar))
+		if len(fields) == 0 {
+			return fmt.Errorf("the checksum of %s in %s is empty", uri, expected)
+		}
+		expected = fields[0]
+	}
+	if _, err := hex.DecodeString(expected); err != nil || len(expected) != sha256.Size*2 {
+		return fmt.Errorf("invalid %s, expected a SHA-256 hex digest or an s3 URI", EnvChecksum)
+	}
+
+	sum := sha256.Sum256(content)
+	if actual := hex.EncodeToString(sum[:]); !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("the SHA-256 checksum of %s is %s, expected %s", uri, actual, expected)
+	}
+	return nil
+}
+
+func (p *provider) Scheme() string {
+	return schemeName
+}
+
+func (p *provider) Shutdown(context.Context) error {
+	return nil
+}
+
+// splitURI returns the region, bucket and key of an s3://<bucket>.s3.<region>.amazonaws.com/<key> URI.
+func splitURI(uri string) (string, string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid uri %q: %w", uri, err)
+	}
+	parts := strings.Split(u.Host, ".")
+	if len(parts) < 5 || parts[len(parts)-4] != "s3" || parts[len(parts)-2] != "amazonaws" {
+		return "", "", "", fmt.Errorf("invalid uri %q, expected s3://<bucket>.s3.<region>.amazonaws.com/<key>", uri)
+	}
+	bucket := strings.Join(parts[:len(parts)-4], ".")
+	region := parts[len(parts)-3]
+	key := strings.TrimPrefix(u.Path, "/")
+	if key == "" {
+		return "", "", "", fmt.Errorf("invalid uri %q, the key is empty", uri)
+	}
+	return region, bucket, key, nil
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package s3provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrieve(t *testing.T) {
+	var region, bucket, key string
+	p := &provider{get: func(_ context.Context, r, b, k string) (io.ReadCloser, error) {
+		region, bucket, key = r, b, k
+		return io.NopCloser(strings.NewReader("receivers:\n  otlp:\n")), nil
+	}}
+
+	ret, err := p.Retrieve(context.Background(), "s3://platform.config.s3.eu-west-1.amazonaws.com/lambda/collector.yaml", nil)
+	require.NoError(t, err)
+	conf, err := ret.AsConf()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"receivers": map[string]interface{}{"otlp": nil}}, conf.ToStringMap())
+	assert.Equal(t, "eu-west-1", region)
+	assert.Equal(t, "platform.config", bucket)
+	assert.Equal(t, "lambda/collector.yaml", key)
+}
+
+func TestRetrieveFails(t *testing.T) {
+	p := &provider{get: func(context.Context, string, string, string) (io.ReadCloser, error) {
+		return nil, errors.New("access denied")
+	}}
+	_, err := p.Retrieve(context.Background(), "s3://bucket.s3.us-east-1.amazonaws.com/config.yaml", nil)
+	assert.ErrorContains(t, err, "access denied")
+
+	p.get = func(context.Context, string, string, string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader("[not a map")), nil
+	}
+	_, err = p.Retrieve(context.Background(), "s3://bucket.s3.us-east-1.amazonaws.com/config.yaml", nil)
+	assert.Error(t, err)
+}
+
+func TestSplitURI(t *testing.T) {
+	for _, uri := range []string{
+		"file:/config.yaml",
+		"s3://bucket/config.yaml",
+		"s3://bucket.s3.us-east-1.example.com/config.yaml",
+		"s3://bucket.s3.us-east-1.amazonaws.com/",
+		"s3://s3.us-east-1.amazonaws.com/config.yaml",
+	} {
+		_, _, _, err := splitURI(uri)
+		assert.Error(t, err, uri)
+	}
+
+	_, err := New("", "").Retrieve(context.Background(), "file:/config.yaml", nil)
+	assert.Error(t, err)
+}
+
+func TestRetrieveVerifiesChecksum(t *testing.T) {
+	content := "receivers:\n  otlp:\n"
+	sum := sha256.Sum256([]byte(content))
+	digest := hex.EncodeToString(sum[:])
+	objects := map[string]string{
+		"config/collector.yaml":       content,
+		"checksums/collector.yaml":    digest + "  collector.yaml\n",
+		"checksums/other.yaml":        strings.Repeat("0", 64) + "  other.yaml\n",
+		"checksums/empty.yaml":        "",
+		"checksums/not-a-digest.yaml": "abc",
+	}
+	get := func(_ context.Context, _, _, key string) (io.ReadCloser, error) {
+		object, ok := objects[key]
+		if !ok {
+			return nil, errors.New("no such key")
+		}
+		return io.NopCloser(strings.NewReader(object)), nil
+	}
+	const uri = "s3://config.s3.us-east-1.amazonaws.com/config/collector.yaml"
+
+	for checksum, valid := range map[string]bool{
+		digest:                  true,
+		strings.ToUpper(digest): true,
+		"s3://checksums.s3.us-east-1.amazonaws.com/checksums/collector.yaml": true,
+		strings.Repeat("0", 64): false,
+		"not a digest":          false,
+		"s3://checksums.s3.us-east-1.amazonaws.com/checksums/other.yaml":        false,
+		"s3://checksums.s3.us-east-1.amazonaws.com/checksums/empty.yaml":        false,
+		"s3://checksums.s3.us-east-1.amazonaws.com/checksums/not-a-digest.yaml": false,
+		"s3://checksums.s3.us-east-1.amazonaws.com/checksums/missing.yaml":      false,
+	} {
+		p := &provider{get: get, configURI: uri, checksum: checksum}
+		_, err := p.Retrieve(context.Background(), uri, nil)
+		if valid {
+			assert.NoError(t, err, checksum)
+		} else {
+			assert.Error(t, err, checksum)
+		}
+	}
+
+	// Values referenced by the configuration are not compared with its checksum.
+	objects["config/exporters.yaml"] = "otlp:\n  endpoint: otlp.example.com:4317\n"
+	p := &provider{get: get, configURI: uri, checksum: digest}
+	_, err := p.Retrieve(context.Background(), "s3://config.s3.us-east-1.amazonaws.com/config/exporters.yaml", nil)
+	assert.NoError(t, err)
+}
@@ -18,10 +18,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/proxy"
 	"go.uber.org/zap"
 )
 
@@ -40,6 +43,8 @@ type NextEventResponse struct {
 	RequestID          string    `json:"requestId"`
 	InvokedFunctionArn string    `json:"invokedFunctionArn"`
 	Tracing            Tracing   `json:"tracing"`
+	// ShutdownReason is only set for SHUTDOWN events
+	ShutdownReason string `json:"shutdownReason"`
 }
 
 // Tracing is part of the response for /event/next
@@ -70,12 +75,20 @@ const (
 	extensionErrorType       = "Lambda-Extension-Function-Error-Type"
 )
 
+const (
+	maxAttempts    = 4
+	initialBackoff = 50 * time.Millisecond
+	maxBackoff     = time.Second
+)
+
 // Client is a simple client for the Lambda Extensions API.
 type Client struct {
 	baseURL     string
 	httpClient  *http.Client
 	extensionID string
-	logger      *zap.Logger
+	// extensionName is kept to register again when the extension identifier is rejected.
+	extensionName string
+	logger        *zap.Logger
 }
 
 // NewClient returns a Lambda Extensions API client.
@@ -83,7 +96,7 @@ func NewClient(logger *zap.Logger, awsLambdaRuntimeAPI string) *Client {
 	baseURL := fmt.Sprintf("http://%s/2020-01-01/extension", awsLambdaRuntimeAPI)
 	return &Client{
 		baseURL:    baseURL,
-		httpClient: &http.Client{},
+		httpClient: proxy.LambdaAPIClient(),
 		logger:     logger.Named("extensionAPI.Client"),
 	}
 }
@@ -99,18 +112,23 @@ func (e *Client) Register(ctx context.Context, filename string) (*RegisterRespon
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(extensionNameHeader, filename)
 
 	var registerResp RegisterResponse
-	resp, err := e.doRequest(req, &registerResp)
+	var resp *http.Response
+	err = e.retry(ctx, action, func() error {
+		req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
+		if err != nil {
+			return err
+		}
+		req.Header.Set(extensionNameHeader, filename)
+		resp, err = e.doRequest(req, action, &registerResp)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 	e.extensionID = resp.Header.Get(extensionIdentiferHeader)
+	e.extensionName = filename
 	e.logger.Debug("Registered extension", zap.String("ID", e.extensionID))
 
 	registerResp.ExtensionID = e.extensionID
@@ -118,18 +136,35 @@ func (e *Client) Register(ctx context.Context, filename string) (*RegisterRespon
 }
 
 // NextEvent blocks while long polling for the next lambda invoke or shutdown.
+// Temporary failures are retried, and the extension registers again once if the
+// Extensions API no longer recognizes its identifier.
 func (e *Client) NextEvent(ctx context.Context) (*NextEventResponse, error) {
+	resp, err := e.nextEvent(ctx)
+	if errors.Is(err, ErrInvalidExtensionID) && e.extensionName != "" {
+		e.logger.Warn("Extension identifier rejected, registering again", zap.Error(err))
+		if _, regErr := e.Register(ctx, e.extensionName); regErr != nil {
+			return nil, fmt.Errorf("%w; registering again failed: %v", err, regErr)
+		}
+		resp, err = e.nextEvent(ctx)
+	}
+	return resp, err
+}
+
+func (e *Client) nextEvent(ctx context.Context) (*NextEventResponse, error) {
 	const action = "/event/next"
 	url := e.baseURL + action
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-	req.Header.Set(extensionIdentiferHeader, e.extensionID)
-
 	var nextEventResp NextEventResponse
-	if _, err := e.doRequest(req, &nextEventResp); err != nil {
+	err := e.retry(ctx, action, func() error {
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set(extensionIdentiferHeader, e.extensionID)
+		_, err = e.doRequest(req, action, &nextEventResp)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &nextEventResp, nil
@@ -149,7 +184,7 @@ func (e *Client) InitError(ctx context.Context, errorType string) (*StatusRespon
 	req.Header.Set(extensionErrorType, errorType)
 
 	var statusResp StatusResponse
-	if _, err := e.doRequest(req, &statusResp); err != nil {
+	if _, err := e.doRequest(req, action, &statusResp); err != nil {
 		return nil, err
 	}
 	return &statusResp, nil
@@ -169,22 +204,59 @@ func (e *Client) ExitError(ctx context.Context, errorType string) (*StatusRespon
 	req.Header.Set(extensionErrorType, errorType)
 
 	var statusResp StatusResponse
-	if _, err := e.doRequest(req, &statusResp); err != nil {
+	if _, err := e.doRequest(req, action, &statusResp); err != nil {
 		return nil, err
 	}
 	return &statusResp, nil
 }
 
-func (e *Client) doRequest(req *http.Request, out interface{}) (*http.Response, error) {
+// retry calls do until it succeeds, fails permanently or maxAttempts is reached, backing
+// off exponentially between attempts. Context errors are never retried.
+func (e *Client) retry(ctx context.Context, action string, do func() error) error {
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		err := do()
+		if err == nil || attempt == maxAttempts || !isTemporary(ctx, err) {
+			return err
+		}
+		e.logger.Debug("Retrying Extensions API request", zap.String("action", action), zap.Int("attempt", attempt), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func isTemporary(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	// Transport errors, e.g. the connection being reset while the environment thaws.
+	return true
+}
+
+func (e *Client) doRequest(req *http.Request, action string, out interface{}) (*http.Response, error) {
 	resp, err := e.httpClient.Do(req)
 	if err != nil {
 		return nil, err
 	}
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("request failed with status %s", resp.Status)
-	}
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{Action: action, StatusCode: resp.StatusCode}
+		if err == nil {
+			_ = json.Unmarshal(body, apiErr)
+		}
+		return nil, apiErr
+	}
 	if err != nil {
 		return nil, err
 	}
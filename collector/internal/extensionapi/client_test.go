@@ -0,0 +1,113 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensionapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewClient(zap.NewNop(), strings.TrimPrefix(srv.URL, "http://"))
+}
+
+func TestNextEventRetriesTemporaryErrors(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"eventType":"INVOKE","requestId":"1"}`))
+	})
+
+	res, err := c.NextEvent(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1", res.RequestID)
+	assert.EqualValues(t, 3, atomic.LoadInt32(&calls))
+}
+
+func TestNextEventDoesNotRetryPermanentErrors(t *testing.T) {
+	var calls int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"errorMessage":"State transition failed","errorType":"Extension.Unknown"}`))
+	})
+
+	_, err := c.NextEvent(context.Background())
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+	assert.Equal(t, "Extension.Unknown", apiErr.ErrorType)
+	assert.Equal(t, "/event/next failed with status 500: Extension.Unknown: State transition failed", apiErr.Error())
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestNextEventRegistersAgain(t *testing.T) {
+	var registrations int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/2020-01-01/extension/register":
+			n := atomic.AddInt32(&registrations, 1)
+			w.Header().Set(extensionIdentiferHeader, strings.Repeat("id", int(n)))
+			_, _ = w.Write([]byte(`{}`))
+		case "/2020-01-01/extension/event/next":
+			if r.Header.Get(extensionIdentiferHeader) != "idid" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"eventType":"SHUTDOWN","shutdownReason":"spindown"}`))
+		}
+	})
+
+	_, err := c.Register(context.Background(), "collector")
+	require.NoError(t, err)
+	res, err := c.NextEvent(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Shutdown, res.EventType)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&registrations))
+}
+
+func TestNextEventInvalidExtensionID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	_, err := c.NextEvent(context.Background())
+	assert.ErrorIs(t, err, ErrInvalidExtensionID)
+}
+
+func TestNextEventContextCanceled(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.NextEvent(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extensionapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrInvalidExtensionID is matched by errors returned when the Extensions API does not
+// recognize the extension identifier, e.g. after the registration was lost.
+var ErrInvalidExtensionID = errors.New("invalid extension identifier")
+
+// APIError is returned when the Extensions API answers a request with an error status.
+type APIError struct {
+	Action     string
+	StatusCode int
+	// ErrorType and ErrorMessage are decoded from the error body, when present.
+	ErrorType    string `json:"errorType"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s failed with status %d", e.Action, e.StatusCode)
+	if e.ErrorType != "" {
+		msg += ": " + e.ErrorType
+	}
+	if e.ErrorMessage != "" {
+		msg += ": " + e.ErrorMessage
+	}
+	return msg
+}
+
+// Is makes errors.Is(err, ErrInvalidExtensionID) hold for 403 responses.
+func (e *APIError) Is(target error) bool {
+	return target == ErrInvalidExtensionID && e.StatusCode == http.StatusForbidden
+}
+
+// Temporary reports whether the request may succeed if retried. The Extensions API
+// answers 500 when the environment is in a non-recoverable state, so only throttling
+// and gateway errors are retried.
+func (e *APIError) Temporary() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
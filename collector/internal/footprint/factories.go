@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprint
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+type receiverFactory struct {
+	component.ReceiverFactory
+	report *Report
+}
+
+func (f *receiverFactory) CreateTracesReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Traces) (component.TracesReceiver, error) {
+	defer f.report.measure(set.ID)()
+	return f.ReceiverFactory.CreateTracesReceiver(ctx, set, cfg, next)
+}
+
+func (f *receiverFactory) CreateMetricsReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Metrics) (component.MetricsReceiver, error) {
+	defer f.report.measure(set.ID)()
+	return f.ReceiverFactory.CreateMetricsReceiver(ctx, set, cfg, next)
+}
+
+func (f *receiverFactory) CreateLogsReceiver(ctx context.Context, set component.ReceiverCreateSettings, cfg component.Config, next consumer.Logs) (component.LogsReceiver, error) {
+	defer f.report.measure(set.ID)()
+	return f.ReceiverFactory.CreateLogsReceiver(ctx, set, cfg, next)
+}
+
+type processorFactory struct {
+	component.ProcessorFactory
+	report *Report
+}
+
+func (f *processorFactory) CreateTracesProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg component.Config, next consumer.Traces) (component.TracesProcessor, error) {
+	defer f.report.measure(set.ID)()
+	return f.ProcessorFactory.CreateTracesProcessor(ctx, set, cfg, next)
+}
+
+func (f *processorFactory) CreateMetricsProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg component.Config, next consumer.Metrics) (component.MetricsProcessor, error) {
+	defer f.report.measure(set.ID)()
+	return f.ProcessorFactory.CreateMetricsProcessor(ctx, set, cfg, next)
+}
+
+func (f *processorFactory) CreateLogsProcessor(ctx context.Context, set component.ProcessorCreateSettings, cfg component.Config, next consumer.Logs) (component.LogsProcessor, error) {
+	defer f.report.measure(set.ID)()
+	return f.ProcessorFactory.CreateLogsProcessor(ctx, set, cfg, next)
+}
+
+type exporterFactory struct {
+	component.ExporterFactory
+	report *Report
+}
+
+func (f *exporterFactory) CreateTracesExporter(ctx context.Context, set component.ExporterCreateSettings, cfg component.Config) (component.TracesExporter, error) {
+	defer f.report.measure(set.ID)()
+	return f.ExporterFactory.CreateTracesExporter(ctx, set, cfg)
+}
+
+func (f *exporterFactory) CreateMetricsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg component.Config) (component.MetricsExporter, error) {
+	defer f.report.measure(set.ID)()
+	return f.ExporterFactory.CreateMetricsExporter(ctx, set, cfg)
+}
+
+func (f *exporterFactory) CreateLogsExporter(ctx context.Context, set component.ExporterCreateSettings, cfg component.Config) (component.LogsExporter, error) {
+	defer f.report.measure(set.ID)()
+	return f.ExporterFactory.CreateLogsExporter(ctx, set, cfg)
+}
+
+type extensionFactory struct {
+	component.ExtensionFactory
+	report *Report
+}
+
+func (f *extensionFactory) CreateExtension(ctx context.Context, set component.ExtensionCreateSettings, cfg component.Config) (component.Extension, error) {
+	defer f.report.measure(set.ID)()
+	return f.ExtensionFactory.CreateExtension(ctx, set, cfg)
+}
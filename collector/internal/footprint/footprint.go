@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package footprint estimates the memory allocated by each component of the collector as it is created, so that
+// users can see which components are worth removing from their build of the layer.
+package footprint // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/footprint"
+
+import (
+	"context"
+	"runtime/metrics"
+	"sort"
+	"sync"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// allocsMetric is the cumulative count of bytes allocated on the heap by the process.
+const allocsMetric = "/gc/heap/allocs:bytes"
+
+var (
+	componentKey = tag.MustNewKey("component")
+
+	statAllocated = stats.Int64("lambda_extension_component_allocated", "Memory allocated on the heap while creating a component", stats.UnitBytes)
+)
+
+// MetricViews returns the metrics views emitted by Report.Record.
+// They are exposed through the collector's own telemetry once registered with view.Register.
+func MetricViews() []*view.View {
+	return []*view.View{{
+		Name:        statAllocated.Name(),
+		Measure:     statAllocated,
+		Description: statAllocated.Description(),
+		TagKeys:     []tag.Key{componentKey},
+		Aggregation: view.LastValue(),
+	}}
+}
+
+// Report holds the memory allocated while creating each component. It is approximate: the allocations of other
+// goroutines running meanwhile are counted too, and those made when the component starts are not.
+type Report struct {
+	mu        sync.Mutex
+	allocated map[component.ID]uint64
+}
+
+// Wrap returns factories measuring, in the returned Report, the memory allocated by the components they create.
+func Wrap(factories component.Factories) (component.Factories, *Report) {
+	r := &Report{allocated: make(map[component.ID]uint64)}
+	wrapped := component.Factories{
+		Receivers:  make(map[component.Type]component.ReceiverFactory, len(factories.Receivers)),
+		Processors: make(map[component.Type]component.ProcessorFactory, len(factories.Processors)),
+		Exporters:  make(map[component.Type]component.ExporterFactory, len(factories.Exporters)),
+		Extensions: make(map[component.Type]component.ExtensionFactory, len(factories.Extensions)),
+	}
+	for typ, f := range factories.Receivers {
+		wrapped.Receivers[typ] = &receiverFactory{ReceiverFactory: f, report: r}
+	}
+	for typ, f := range factories.Processors {
+		wrapped.Processors[typ] = &processorFactory{ProcessorFactory: f, report: r}
+	}
+	for typ, f := range factories.Exporters {
+		wrapped.Exporters[typ] = &exporterFactory{ExporterFactory: f, report: r}
+	}
+	for typ, f := range factories.Extensions {
+		wrapped.Extensions[typ] = &extensionFactory{ExtensionFactory: f, report: r}
+	}
+	return wrapped, r
+}
+
+// measure starts measuring the allocations made to create the component id and returns the function that
+// ends it. Components created once per signal add up.
+func (r *Report) measure(id component.ID) func() {
+	start := allocated()
+	return func() {
+		delta := allocated() - start
+		r.mu.Lock()
+		r.allocated[id] += delta
+		r.mu.Unlock()
+	}
+}
+
+// Fields returns the memory allocated by each component created, in bytes, as structured log fields.
+func (r *Report) Fields() []zap.Field {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	fields := make([]zap.Field, 0, len(r.allocated))
+	for id, bytes := range r.allocated {
+		fields = append(fields, zap.Uint64(id.String(), bytes))
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	return fields
+}
+
+// Record records the memory allocated by each component created as metrics.
+func (r *Report) Record(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, bytes := range r.allocated {
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(componentKey, id.String())}, statAllocated.M(int64(bytes)))
+	}
+}
+
+func allocated() uint64 {
+	sample := []metrics.Sample{{Name: allocsMetric}}
+	metrics.Read(sample)
+	if sample[0].Value.Kind() != metrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
@@ -0,0 +1,61 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package footprint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+var sink []byte
+
+func TestWrap(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	factory := component.NewExtensionFactory("test", func() component.Config { return nil },
+		func(context.Context, component.ExtensionCreateSettings, component.Config) (component.Extension, error) {
+			sink = make([]byte, 1<<20)
+			return componenttest.NewNopExtensionFactory().CreateExtension(context.Background(), componenttest.NewNopExtensionCreateSettings(), nil)
+		}, component.StabilityLevelStable)
+	factories, report := Wrap(component.Factories{Extensions: map[component.Type]component.ExtensionFactory{"test": factory}})
+
+	wrapped := factories.Extensions["test"]
+	assert.Equal(t, component.Type("test"), wrapped.Type())
+	assert.Equal(t, component.StabilityLevelStable, wrapped.ExtensionStability())
+
+	set := componenttest.NewNopExtensionCreateSettings()
+	set.ID = component.NewIDWithName("test", "1")
+	_, err := wrapped.CreateExtension(context.Background(), set, nil)
+	require.NoError(t, err)
+
+	fields := report.Fields()
+	require.Len(t, fields, 1)
+	assert.Equal(t, "test/1", fields[0].Key)
+	assert.GreaterOrEqual(t, fields[0].Integer, int64(1<<20))
+
+	report.Record(context.Background())
+	rows, err := view.RetrieveData(statAllocated.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.GreaterOrEqual(t, rows[0].Data.(*view.LastValueData).Value, float64(1<<20))
+}
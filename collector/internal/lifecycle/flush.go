@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// EnvFlushEndpoint is the environment variable holding the local address, e.g. localhost:4324, on which the
+// extension serves POST /flush. It is not served when the variable is empty.
+const EnvFlushEndpoint = "OPENTELEMETRY_EXTENSION_FLUSH_ENDPOINT"
+
+// FlushServer lets function code send the telemetry held by the extension before it responds, by flushing the
+// listeners of a Notifier when /flush is posted to. It returns 204 No Content once they are flushed, or 500 if
+// any of them failed.
+type FlushServer struct {
+	logger   *zap.Logger
+	notifier *Notifier
+	server   *http.Server
+}
+
+// NewFlushServer returns a FlushServer flushing the listeners of notifier.
+func NewFlushServer(logger *zap.Logger, notifier *Notifier) *FlushServer {
+	return &FlushServer{logger: logger.Named("flush"), notifier: notifier}
+}
+
+// Start listens on addr and serves requests in a goroutine.
+func (s *FlushServer) Start(addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/flush", s)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: time.Second}
+	go func() {
+		if err := s.server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("Unexpected stop of the flush endpoint", zap.Error(err))
+		}
+	}()
+	s.logger.Info("Serving flush requests", zap.String("address", ln.Addr().String()))
+	return nil
+}
+
+// Shutdown stops serving requests, waiting up to a second for those in progress. It does nothing on a nil
+// FlushServer.
+func (s *FlushServer) Shutdown() {
+	if s == nil || s.server == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Warn("Failed to shut the flush endpoint down", zap.Error(err))
+	}
+}
+
+func (s *FlushServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err := s.notifier.Flush(r.Context()); err != nil {
+		s.logger.Warn("Failed to flush", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
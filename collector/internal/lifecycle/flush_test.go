@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type flushingListener struct {
+	recordingListener
+	err error
+}
+
+func (f *flushingListener) OnFlush(context.Context) error {
+	f.record("flush")
+	return f.err
+}
+
+func TestFlushServer(t *testing.T) {
+	n := NewNotifier()
+	plain, flushing := &recordingListener{}, &flushingListener{}
+	n.AddListener(plain)
+	n.AddListener(flushing)
+	s := NewFlushServer(zap.NewNop(), n)
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/flush", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	assert.Empty(t, flushing.events)
+
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/flush", nil))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, []string{"flush"}, flushing.events)
+	assert.Empty(t, plain.events)
+
+	flushing.err = errors.New("export failed")
+	rec = httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/flush", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestFlushServerStart(t *testing.T) {
+	s := NewFlushServer(zap.NewNop(), NewNotifier())
+	assert.NoError(t, s.Start("localhost:0"))
+	s.Shutdown()
+
+	var stopped *FlushServer
+	stopped.Shutdown()
+}
@@ -0,0 +1,151 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+)
+
+// Phases of the extension's initialization, in the order they happen.
+const (
+	PhaseRegister           = "register"
+	PhaseTelemetryListener  = "telemetry_listener"
+	PhaseTelemetrySubscribe = "telemetry_subscribe"
+	PhaseSecrets            = "secrets"
+	PhaseFactories          = "factories"
+	PhaseConfigResolve      = "config_resolve"
+	PhaseServiceStart       = "service_start"
+
+	phaseTotal = "total"
+
+	// EnvInitBudget is the environment variable holding the duration the initialization of the extension is
+	// expected to take at most, e.g. 200ms.
+	EnvInitBudget = "OPENTELEMETRY_EXTENSION_INIT_BUDGET"
+)
+
+var (
+	phaseKey = tag.MustNewKey("phase")
+
+	statInitDuration       = stats.Float64("lambda_extension_init_duration", "Time spent in each phase of the extension's initialization", stats.UnitMilliseconds)
+	statInitBudgetExceeded = stats.Int64("lambda_extension_init_budget_exceeded", "Whether the extension's initialization exceeded its budget (1) or not (0)", stats.UnitDimensionless)
+)
+
+// InitMetricViews returns the metrics views emitted by InitBreakdown.Done and InitBreakdown.CheckBudget.
+// They are exposed through the collector's own telemetry once registered with view.Register.
+func InitMetricViews() []*view.View {
+	return []*view.View{{
+		Name:        statInitDuration.Name(),
+		Measure:     statInitDuration,
+		Description: statInitDuration.Description(),
+		TagKeys:     []tag.Key{phaseKey},
+		Aggregation: view.LastValue(),
+	}, {
+		Name:        statInitBudgetExceeded.Name(),
+		Measure:     statInitBudgetExceeded,
+		Description: statInitBudgetExceeded.Description(),
+		Aggregation: view.LastValue(),
+	}}
+}
+
+// InitPhase is the time spent in one phase of the initialization.
+type InitPhase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// InitBreakdown records how long each phase of the extension's initialization took, so that the
+// contribution of the extension to cold starts can be attributed. Phases running concurrently may be
+// recorded from different goroutines.
+type InitBreakdown struct {
+	start time.Time
+	total time.Duration
+
+	mu     sync.Mutex
+	phases []InitPhase
+}
+
+// NewInitBreakdown returns an InitBreakdown for an initialization started at start.
+func NewInitBreakdown(start time.Time) *InitBreakdown {
+	return &InitBreakdown{start: start}
+}
+
+// Record adds a phase that took d.
+func (b *InitBreakdown) Record(name string, d time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.phases = append(b.phases, InitPhase{Name: name, Duration: d})
+}
+
+// Track starts timing the named phase and returns the function that ends it.
+func (b *InitBreakdown) Track(name string) func() {
+	start := time.Now()
+	return func() {
+		b.Record(name, time.Since(start))
+	}
+}
+
+// Phases returns the phases recorded so far.
+func (b *InitBreakdown) Phases() []InitPhase {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]InitPhase(nil), b.phases...)
+}
+
+// Done ends the initialization and records the duration of every phase, and of the whole
+// initialization, as metrics.
+func (b *InitBreakdown) Done(ctx context.Context) {
+	b.total = time.Since(b.start)
+	for _, p := range b.Phases() {
+		recordInitDuration(ctx, p.Name, p.Duration)
+	}
+	recordInitDuration(ctx, phaseTotal, b.total)
+}
+
+// Total returns the duration of the whole initialization, once Done.
+func (b *InitBreakdown) Total() time.Duration {
+	return b.total
+}
+
+// CheckBudget records whether the initialization, once Done, took longer than budget, and returns it.
+func (b *InitBreakdown) CheckBudget(ctx context.Context, budget time.Duration) bool {
+	exceeded := b.total > budget
+	var value int64
+	if exceeded {
+		value = 1
+	}
+	stats.Record(ctx, statInitBudgetExceeded.M(value))
+	return exceeded
+}
+
+// Fields returns the breakdown as structured log fields, one per phase plus the total.
+func (b *InitBreakdown) Fields() []zap.Field {
+	phases := b.Phases()
+	fields := make([]zap.Field, 0, len(phases)+1)
+	for _, p := range phases {
+		fields = append(fields, zap.Duration(p.Name, p.Duration))
+	}
+	return append(fields, zap.Duration(phaseTotal, b.total))
+}
+
+func recordInitDuration(ctx context.Context, phase string, d time.Duration) {
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(phaseKey, phase)}, statInitDuration.M(float64(d)/float64(time.Millisecond)))
+}
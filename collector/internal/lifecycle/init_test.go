@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+)
+
+func TestInitBreakdown(t *testing.T) {
+	views := InitMetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	b := NewInitBreakdown(time.Now().Add(-time.Second))
+	b.Record(PhaseRegister, 20*time.Millisecond)
+	b.Track(PhaseFactories)()
+	b.Done(context.Background())
+
+	phases := b.Phases()
+	require.Len(t, phases, 2)
+	assert.Equal(t, PhaseRegister, phases[0].Name)
+	assert.Equal(t, PhaseFactories, phases[1].Name)
+	assert.Len(t, b.Fields(), 3)
+
+	rows, err := view.RetrieveData(statInitDuration.Name())
+	require.NoError(t, err)
+	durations := make(map[string]float64)
+	for _, row := range rows {
+		durations[row.Tags[0].Value] = row.Data.(*view.LastValueData).Value
+	}
+	assert.Len(t, durations, 3)
+	assert.Equal(t, 20.0, durations[PhaseRegister])
+	assert.GreaterOrEqual(t, durations[phaseTotal], 1000.0)
+}
+
+func TestInitBreakdownCheckBudget(t *testing.T) {
+	views := InitMetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	b := NewInitBreakdown(time.Now().Add(-300 * time.Millisecond))
+	b.Done(context.Background())
+	assert.GreaterOrEqual(t, b.Total(), 300*time.Millisecond)
+
+	assert.False(t, b.CheckBudget(context.Background(), time.Second))
+	assert.True(t, b.CheckBudget(context.Background(), 200*time.Millisecond))
+
+	rows, err := view.RetrieveData(statInitBudgetExceeded.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1.0, rows[0].Data.(*view.LastValueData).Value)
+}
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"runtime"
+
+	"go.uber.org/zap"
+)
+
+// EnvLeakDetection is the environment variable enabling the detection of leaks across invocations, when true.
+const EnvLeakDetection = "OPENTELEMETRY_EXTENSION_LEAK_DETECTION"
+
+// leakWindow is the number of consecutive invocations over which goroutines or heap must grow to be reported.
+const leakWindow = 10
+
+// LeakDetector snapshots the goroutines and heap of the extension after each invocation, and reports those
+// that grew after every one of the last invocations, which the steady state of an environment does not do.
+type LeakDetector struct {
+	goroutines []uint64
+	heap       []uint64
+}
+
+// Leak describes a resource that grew over the last invocations.
+type Leak struct {
+	Resource string
+	From     uint64
+	To       uint64
+}
+
+// Fields returns the leak as structured log fields.
+func (l Leak) Fields() []zap.Field {
+	return []zap.Field{
+		zap.String("resource", l.Resource),
+		zap.Uint64("from", l.From),
+		zap.Uint64("to", l.To),
+		zap.Int("invocations", leakWindow),
+	}
+}
+
+// NewLeakDetector returns a LeakDetector.
+func NewLeakDetector() *LeakDetector {
+	return &LeakDetector{}
+}
+
+// Snapshot records the goroutines and the live heap of the extension and returns the leaks detected. It runs a
+// garbage collection, so that the heap does not include garbage.
+func (d *LeakDetector) Snapshot() []Leak {
+	runtime.GC()
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	return d.observe(uint64(runtime.NumGoroutine()), ms.HeapAlloc)
+}
+
+func (d *LeakDetector) observe(goroutines, heap uint64) []Leak {
+	var leaks []Leak
+	var leak *Leak
+	if d.goroutines, leak = grow(d.goroutines, goroutines, "goroutines"); leak != nil {
+		leaks = append(leaks, *leak)
+	}
+	if d.heap, leak = grow(d.heap, heap, "heap"); leak != nil {
+		leaks = append(leaks, *leak)
+	}
+	return leaks
+}
+
+// grow appends value to samples, keeping the last leakWindow+1 of them, and returns the leak of resource if it
+// grew between every sample. Samples are then reset, so that a leak is reported once per window.
+func grow(samples []uint64, value uint64, resource string) ([]uint64, *Leak) {
+	samples = append(samples, value)
+	if len(samples) > leakWindow+1 {
+		samples = samples[1:]
+	}
+	if len(samples) <= leakWindow {
+		return samples, nil
+	}
+	for i := 1; i < len(samples); i++ {
+		if samples[i] <= samples[i-1] {
+			return samples, nil
+		}
+	}
+	return samples[:0], &Leak{Resource: resource, From: samples[0], To: value}
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeakDetector(t *testing.T) {
+	d := NewLeakDetector()
+	for i := 0; i < leakWindow; i++ {
+		assert.Empty(t, d.observe(uint64(10+i), 1000), "not enough invocations")
+	}
+	leaks := d.observe(10+leakWindow, 1000)
+	require.Len(t, leaks, 1)
+	assert.Equal(t, Leak{Resource: "goroutines", From: 10, To: 10 + leakWindow}, leaks[0])
+	assert.Len(t, leaks[0].Fields(), 4)
+
+	assert.Empty(t, d.observe(100, 1000), "reported once per window")
+}
+
+func TestLeakDetectorSteadyState(t *testing.T) {
+	d := NewLeakDetector()
+	for i := 0; i < 3*leakWindow; i++ {
+		// The heap grows, except once in a while.
+		heap := uint64(1000 + i)
+		if i%5 == 0 {
+			heap = 1000
+		}
+		assert.Empty(t, d.observe(10, heap))
+	}
+}
+
+func TestLeakDetectorSnapshot(t *testing.T) {
+	d := NewLeakDetector()
+	assert.Empty(t, d.Snapshot())
+	require.Len(t, d.heap, 1)
+	assert.NotZero(t, d.heap[0])
+	assert.NotZero(t, d.goroutines[0])
+}
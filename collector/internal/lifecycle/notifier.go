@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+)
+
+// Notifier implements lambdalifecycle.Notifier and is driven by the extension's event loop.
+type Notifier struct {
+	mu        sync.Mutex
+	listeners []lambdalifecycle.Listener
+}
+
+var _ lambdalifecycle.Notifier = (*Notifier)(nil)
+
+func NewNotifier() *Notifier {
+	return &Notifier{}
+}
+
+// AddListener registers a listener for all subsequent lifecycle events.
+func (n *Notifier) AddListener(listener lambdalifecycle.Listener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.listeners = append(n.listeners, listener)
+}
+
+// RemoveListener unregisters a listener, e.g. when its component shuts down.
+func (n *Notifier) RemoveListener(listener lambdalifecycle.Listener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, l := range n.listeners {
+		if l == listener {
+			n.listeners = append(n.listeners[:i], n.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// Invoked notifies listeners of a new invocation, in the order they were registered.
+func (n *Notifier) Invoked(ctx context.Context, event lambdalifecycle.InvokeEvent) {
+	for _, l := range n.snapshot() {
+		l.OnInvoke(ctx, event)
+	}
+}
+
+// InitDone notifies the listeners implementing lambdalifecycle.InitListener that the runtime finished
+// initializing at end, in the order they were registered.
+func (n *Notifier) InitDone(ctx context.Context, end time.Time) {
+	for _, l := range n.snapshot() {
+		if il, ok := l.(lambdalifecycle.InitListener); ok {
+			il.OnInitDone(ctx, end)
+		}
+	}
+}
+
+// RuntimeDone notifies listeners that the runtime finished the invocation, in the reverse order they were
+// registered.
+func (n *Notifier) RuntimeDone(ctx context.Context, requestID string) {
+	listeners := n.snapshot()
+	for i := len(listeners) - 1; i >= 0; i-- {
+		listeners[i].OnRuntimeDone(ctx, requestID)
+	}
+}
+
+// Shutdown notifies listeners that the environment is shutting down, in the reverse order they were registered.
+func (n *Notifier) Shutdown(ctx context.Context, reason string) {
+	listeners := n.snapshot()
+	for i := len(listeners) - 1; i >= 0; i-- {
+		listeners[i].OnShutdown(ctx, reason)
+	}
+}
+
+// Flush asks the listeners implementing lambdalifecycle.FlushListener to send the telemetry they hold, in the
+// reverse order they were registered, and returns the first error.
+func (n *Notifier) Flush(ctx context.Context) error {
+	var first error
+	listeners := n.snapshot()
+	for i := len(listeners) - 1; i >= 0; i-- {
+		if fl, ok := listeners[i].(lambdalifecycle.FlushListener); ok {
+			if err := fl.OnFlush(ctx); err != nil && first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// snapshot copies the listeners so that callbacks may add or remove listeners.
+func (n *Notifier) snapshot() []lambdalifecycle.Listener {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]lambdalifecycle.Listener(nil), n.listeners...)
+}
@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingListener struct {
+	name   string
+	events []string
+	// calls records the events of all listeners sharing it, in the order they were delivered.
+	calls *[]string
+}
+
+func (r *recordingListener) record(event string) {
+	r.events = append(r.events, event)
+	if r.calls != nil {
+		*r.calls = append(*r.calls, r.name+":"+event)
+	}
+}
+
+func (r *recordingListener) OnInvoke(_ context.Context, event lambdalifecycle.InvokeEvent) {
+	r.record("invoke:" + event.RequestID)
+}
+
+func (r *recordingListener) OnRuntimeDone(_ context.Context, requestID string) {
+	r.record("runtimeDone:" + requestID)
+}
+
+func (r *recordingListener) OnShutdown(_ context.Context, reason string) {
+	r.record("shutdown:" + reason)
+}
+
+func TestNotifier(t *testing.T) {
+	ctx := context.Background()
+	n := NewNotifier()
+	first, second := &recordingListener{}, &recordingListener{}
+	n.AddListener(first)
+	n.AddListener(second)
+
+	n.Invoked(ctx, lambdalifecycle.InvokeEvent{RequestID: "1"})
+	n.RuntimeDone(ctx, "1")
+	n.RemoveListener(second)
+	n.Shutdown(ctx, "spindown")
+
+	assert.Equal(t, []string{"invoke:1", "runtimeDone:1", "shutdown:spindown"}, first.events)
+	assert.Equal(t, []string{"invoke:1", "runtimeDone:1"}, second.events)
+}
+
+func TestNotifierOrder(t *testing.T) {
+	ctx := context.Background()
+	n := NewNotifier()
+	var calls []string
+	n.AddListener(&recordingListener{name: "processor", calls: &calls})
+	n.AddListener(&recordingListener{name: "receiver", calls: &calls})
+
+	n.Invoked(ctx, lambdalifecycle.InvokeEvent{RequestID: "1"})
+	n.RuntimeDone(ctx, "1")
+	n.Shutdown(ctx, "spindown")
+
+	assert.Equal(t, []string{
+		"processor:invoke:1", "receiver:invoke:1",
+		"receiver:runtimeDone:1", "processor:runtimeDone:1",
+		"receiver:shutdown:spindown", "processor:shutdown:spindown",
+	}, calls)
+}
+
+type initRecordingListener struct {
+	recordingListener
+}
+
+func (r *initRecordingListener) OnInitDone(_ context.Context, end time.Time) {
+	r.record("initDone:" + end.UTC().Format(time.RFC3339))
+}
+
+func TestNotifierInitDone(t *testing.T) {
+	ctx := context.Background()
+	n := NewNotifier()
+	plain, init := &recordingListener{}, &initRecordingListener{}
+	n.AddListener(plain)
+	n.AddListener(init)
+
+	n.InitDone(ctx, time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC))
+
+	assert.Empty(t, plain.events)
+	assert.Equal(t, []string{"initDone:2022-12-01T10:00:00Z"}, init.events)
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+// Views registered by the collector's own telemetry for every exporter.
+var (
+	sentViews   = []string{"exporter/sent_spans", "exporter/sent_metric_points", "exporter/sent_log_records"}
+	failedViews = []string{"exporter/send_failed_spans", "exporter/send_failed_metric_points", "exporter/send_failed_log_records"}
+)
+
+// Summary describes the lifetime of an execution environment, reported when it shuts down.
+type Summary struct {
+	ShutdownReason string
+	Invocations    int
+	Uptime         time.Duration
+	// Exported and Failed are the spans, metric points and log records sent or dropped by
+	// exporters. They are zero when the collector's own metrics are disabled.
+	Exported int64
+	Failed   int64
+}
+
+// Summarize builds the Summary of an environment started at start.
+func Summarize(reason string, invocations int, start time.Time) Summary {
+	return Summary{
+		ShutdownReason: reason,
+		Invocations:    invocations,
+		Uptime:         time.Since(start),
+		Exported:       sumViews(sentViews),
+		Failed:         sumViews(failedViews),
+	}
+}
+
+// Fields returns the summary as structured log fields.
+func (s Summary) Fields() []zap.Field {
+	return []zap.Field{
+		zap.String("shutdownReason", s.ShutdownReason),
+		zap.Int("invocations", s.Invocations),
+		zap.Duration("uptime", s.Uptime),
+		zap.Int64("exported", s.Exported),
+		zap.Int64("failed", s.Failed),
+	}
+}
+
+func sumViews(names []string) int64 {
+	var total float64
+	for _, name := range names {
+		rows, err := view.RetrieveData(name)
+		if err != nil {
+			continue
+		}
+		for _, row := range rows {
+			if sum, ok := row.Data.(*view.SumData); ok {
+				total += sum.Value
+			}
+		}
+	}
+	return int64(total)
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+)
+
+func TestSummarize(t *testing.T) {
+	sent := stats.Int64("exporter/sent_spans", "", stats.UnitDimensionless)
+	failed := stats.Int64("exporter/send_failed_log_records", "", stats.UnitDimensionless)
+	views := []*view.View{
+		{Name: sent.Name(), Measure: sent, Aggregation: view.Sum()},
+		{Name: failed.Name(), Measure: failed, Aggregation: view.Sum()},
+	}
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	stats.Record(context.Background(), sent.M(10), sent.M(5), failed.M(2))
+
+	s := Summarize("spindown", 3, time.Now().Add(-time.Minute))
+	assert.Equal(t, "spindown", s.ShutdownReason)
+	assert.Equal(t, 3, s.Invocations)
+	assert.GreaterOrEqual(t, s.Uptime, time.Minute)
+	assert.EqualValues(t, 15, s.Exported)
+	assert.EqualValues(t, 2, s.Failed)
+	assert.Len(t, s.Fields(), 5)
+}
+
+func TestSummarizeWithoutViews(t *testing.T) {
+	s := Summarize("timeout", 0, time.Now())
+	assert.Zero(t, s.Exported)
+	assert.Zero(t, s.Failed)
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logging rate limits the logs of the extension and of the collector, which are written to the
+// function's CloudWatch Logs stream and billed to its owner.
+package logging // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/logging"
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// tick is the period over which identical entries are counted. It spans many invocations, so that an error
+	// logged on every invocation, e.g. by a failing exporter, is sampled too.
+	tick = time.Minute
+	// first entries with the same level and message are logged each tick, then one in thereafter.
+	first      = 10
+	thereafter = 100
+)
+
+// Sampler samples repeated log entries and counts those it drops.
+type Sampler struct {
+	suppressed atomic.Int64
+
+	mu         sync.Mutex
+	lastReport time.Time
+}
+
+// NewSampler returns a Sampler.
+func NewSampler() *Sampler {
+	return &Sampler{lastReport: time.Now()}
+}
+
+// Wrap returns core sampling the entries logged through it.
+func (s *Sampler) Wrap(core zapcore.Core) zapcore.Core {
+	return zapcore.NewSamplerWithOptions(core, tick, first, thereafter, zapcore.SamplerHook(s.hook))
+}
+
+// Option returns the zap.Option sampling the entries of a logger.
+func (s *Sampler) Option() zap.Option {
+	return zap.WrapCore(s.Wrap)
+}
+
+func (s *Sampler) hook(_ zapcore.Entry, dec zapcore.SamplingDecision) {
+	if dec&zapcore.LogDropped != 0 {
+		s.suppressed.Add(1)
+	}
+}
+
+// Report returns the number of entries dropped since the last report, at most once per tick. It returns
+// false if the previous report is more recent or if no entries were dropped since.
+func (s *Sampler) Report(now time.Time) (int64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastReport) < tick {
+		return 0, false
+	}
+	s.lastReport = now
+	n := s.suppressed.Swap(0)
+	return n, n > 0
+}
+
+// Suppressed returns the number of entries dropped since the last report.
+func (s *Sampler) Suppressed() int64 {
+	return s.suppressed.Load()
+}
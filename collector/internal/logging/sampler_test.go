@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSampler(t *testing.T) {
+	s := NewSampler()
+	core, logs := observer.New(zap.InfoLevel)
+	logger := zap.New(s.Wrap(core))
+
+	for i := 0; i < first+2*thereafter; i++ {
+		logger.Error("Exporting failed")
+	}
+	logger.Info("Other entry")
+
+	assert.Equal(t, 1, logs.FilterMessage("Other entry").Len())
+	assert.Equal(t, first+2, logs.FilterMessage("Exporting failed").Len())
+	assert.EqualValues(t, 2*thereafter-2, s.Suppressed())
+
+	_, ok := s.Report(time.Now())
+	assert.False(t, ok, "reported within a tick")
+
+	n, ok := s.Report(time.Now().Add(tick))
+	assert.True(t, ok)
+	assert.EqualValues(t, 2*thereafter-2, n)
+	assert.Zero(t, s.Suppressed())
+
+	_, ok = s.Report(time.Now().Add(3 * tick))
+	assert.False(t, ok, "nothing dropped since the last report")
+}
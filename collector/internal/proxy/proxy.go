@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package proxy applies the proxy settings of the environment, HTTPS_PROXY, HTTP_PROXY and NO_PROXY, the same way
+// to all the outbound traffic of the extension.
+package proxy // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/proxy"
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// EnvExemptLambdaAPIs is the environment variable which, set to true, sends the requests to the Extensions and
+// Telemetry APIs of the execution environment directly, even when the proxy settings would apply to them.
+const EnvExemptLambdaAPIs = "OPENTELEMETRY_EXTENSION_PROXY_EXEMPT_LAMBDA_APIS"
+
+// LambdaAPIClient returns the HTTP client for the APIs of the execution environment. They are served on the
+// loopback address, which is never proxied, unless they run elsewhere, e.g. in an emulator.
+func LambdaAPIClient() *http.Client {
+	if os.Getenv(EnvExemptLambdaAPIs) != "true" {
+		return &http.Client{}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	return &http.Client{Transport: transport}
+}
+
+// For returns the proxy of requests to rawURL according to the environment, or an empty string if they are
+// sent directly.
+func For(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	proxyURL, err := httpproxy.FromEnvironment().ProxyFunc()(u)
+	if err != nil || proxyURL == nil {
+		return "", err
+	}
+	return proxyURL.String(), nil
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFor(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "http://proxy.corp:3128")
+	t.Setenv("NO_PROXY", ".internal.corp,169.254.169.254")
+
+	proxy, err := For("https://xray.us-east-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, "http://proxy.corp:3128", proxy)
+
+	for _, direct := range []string{"https://backend.internal.corp", "http://127.0.0.1:9001", "http://localhost:4318"} {
+		proxy, err = For(direct)
+		require.NoError(t, err)
+		assert.Empty(t, proxy, direct)
+	}
+}
+
+func TestLambdaAPIClient(t *testing.T) {
+	assert.Nil(t, LambdaAPIClient().Transport)
+
+	t.Setenv(EnvExemptLambdaAPIs, "true")
+	transport, ok := LambdaAPIClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Nil(t, transport.Proxy)
+}
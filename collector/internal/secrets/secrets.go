@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets fetches secrets from AWS Secrets Manager for the collector, so that they do not have to be
+// stored in the function's environment or packaged with it.
+package secrets // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/secrets"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/awssession"
+)
+
+// EnvSecretFiles is the environment variable listing the secrets to write to files before the collector starts,
+// as comma-separated path=secret pairs, where the secret is the name or ARN of a Secrets Manager secret, e.g.
+// /tmp/certs/server.key=arn:aws:secretsmanager:us-east-1:123456789012:secret:otlp-server-key.
+const EnvSecretFiles = "OPENTELEMETRY_EXTENSION_SECRET_FILES"
+
+// Getter returns the value of a secret.
+type Getter interface {
+	GetSecret(ctx context.Context, id string) (string, error)
+}
+
+type secretsManager struct {
+	client *secretsmanager.SecretsManager
+}
+
+// NewSecretsManager returns a Getter fetching secrets from Secrets Manager with the function's role, in the
+// function's region.
+func NewSecretsManager() (Getter, error) {
+	sess, err := awssession.New()
+	if err != nil {
+		return nil, err
+	}
+	return &secretsManager{client: secretsmanager.New(sess)}, nil
+}
+
+func (s *secretsManager) GetSecret(ctx context.Context, id string) (string, error) {
+	out, err := s.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	if err != nil {
+		return "", fmt.Errorf("cannot get secret %q: %w", id, err)
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+type secretFile struct {
+	path   string
+	secret string
+}
+
+// WriteFiles writes the secrets listed in spec, in the format of EnvSecretFiles, to their files, and returns the
+// paths it wrote, including when it fails. The files are only readable by their owner, but the function and its
+// extensions run as the same user, so the function can read them too: they must be removed with RemoveFiles as
+// soon as the collector has loaded them, which is the only protection they get.
+func WriteFiles(ctx context.Context, getter Getter, spec string) ([]string, error) {
+	files, err := parseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	var written []string
+	for _, f := range files {
+		value, err := getter.GetSecret(ctx, f.secret)
+		if err != nil {
+			return written, err
+		}
+		if err = os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+			return written, err
+		}
+		if err = os.WriteFile(f.path, []byte(value), 0600); err != nil {
+			return written, err
+		}
+		written = append(written, f.path)
+	}
+	return written, nil
+}
+
+// RemoveFiles removes the files written by WriteFiles, and returns the first error.
+func RemoveFiles(paths []string) error {
+	var first error
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+func parseSpec(spec string) ([]secretFile, error) {
+	var files []secretFile
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		path, secret, ok := strings.Cut(entry, "=")
+		if !ok || path == "" || secret == "" {
+			return nil, fmt.Errorf("invalid %s entry %q, expected path=secret", EnvSecretFiles, entry)
+		}
+		files = append(files, secretFile{path: path, secret: secret})
+	}
+	return files, nil
+}
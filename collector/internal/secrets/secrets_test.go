@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type getterFunc func(ctx context.Context, id string) (string, error)
+
+func (f getterFunc) GetSecret(ctx context.Context, id string) (string, error) {
+	return f(ctx, id)
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	getter := getterFunc(func(_ context.Context, id string) (string, error) {
+		return "value of " + id, nil
+	})
+	cert := filepath.Join(dir, "certs", "server.crt")
+	key := filepath.Join(dir, "certs", "server.key")
+	spec := cert + "=server-cert, " + key + "=arn:aws:secretsmanager:us-east-1:123456789012:secret:server-key"
+
+	written, err := WriteFiles(context.Background(), getter, spec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{cert, key}, written)
+
+	b, err := os.ReadFile(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "value of server-cert", string(b))
+	b, err = os.ReadFile(key)
+	require.NoError(t, err)
+	assert.Equal(t, "value of arn:aws:secretsmanager:us-east-1:123456789012:secret:server-key", string(b))
+	info, err := os.Stat(key)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	require.NoError(t, RemoveFiles(written))
+	assert.NoFileExists(t, cert)
+	assert.NoFileExists(t, key)
+	assert.NoError(t, RemoveFiles(written), "already removed")
+}
+
+func TestWriteFilesErrors(t *testing.T) {
+	getter := getterFunc(func(context.Context, string) (string, error) {
+		return "", errors.New("access denied")
+	})
+	_, err := WriteFiles(context.Background(), getter, filepath.Join(t.TempDir(), "key")+"=secret")
+	assert.EqualError(t, err, "access denied")
+	_, err = WriteFiles(context.Background(), getter, "/tmp/key")
+	assert.Error(t, err)
+	_, err = WriteFiles(context.Background(), getter, "=secret")
+	assert.Error(t, err)
+	_, err = WriteFiles(context.Background(), getter, "")
+	assert.NoError(t, err)
+}
+
+func TestWriteFilesReturnsFilesWrittenBeforeAnError(t *testing.T) {
+	dir := t.TempDir()
+	getter := getterFunc(func(_ context.Context, id string) (string, error) {
+		if id == "missing" {
+			return "", errors.New("access denied")
+		}
+		return "value", nil
+	})
+	cert := filepath.Join(dir, "server.crt")
+
+	written, err := WriteFiles(context.Background(), getter, cert+"=server-cert,"+filepath.Join(dir, "server.key")+"=missing")
+	assert.Error(t, err)
+	assert.Equal(t, []string{cert}, written)
+}
@@ -18,34 +18,80 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"go.uber.org/zap"
 	"io"
 	"net/http"
 	"os"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/proxy"
+	"go.uber.org/zap"
 )
 
 const (
 	SchemaVersion20220701          = "2022-07-01"
-	SchemaVersionLatest            = SchemaVersion20220701
+	SchemaVersion20221213          = "2022-12-13"
+	SchemaVersionLatest            = SchemaVersion20221213
+	apiVersion                     = "2022-07-01"
 	lambdaAgentIdentifierHeaderKey = "Lambda-Extension-Identifier"
+	schemaVersionEnvKey            = "OPENTELEMETRY_EXTENSION_TELEMETRY_SCHEMA_VERSION"
 )
 
+// supportedSchemaVersions lists the schema versions understood by the extension, newest first.
+// Subscribe walks this list until the Telemetry API accepts one of them.
+var supportedSchemaVersions = []SchemaVersion{SchemaVersion20221213, SchemaVersion20220701}
+
+var errUnsupportedSchema = errors.New("schema version not accepted")
+
 type Client struct {
-	logger     *zap.Logger
-	httpClient *http.Client
-	baseURL    string
+	logger         *zap.Logger
+	httpClient     *http.Client
+	baseURL        string
+	schemaVersions []SchemaVersion
+	schemaVersion  SchemaVersion
 }
 
 func NewClient(logger *zap.Logger) *Client {
-	return &Client{
-		logger:     logger.Named("telemetryAPI.Client"),
-		httpClient: &http.Client{},
-		baseURL:    fmt.Sprintf("http://%s/%s/telemetry", os.Getenv("AWS_LAMBDA_RUNTIME_API"), SchemaVersionLatest),
+	c := &Client{
+		logger:         logger.Named("telemetryAPI.Client"),
+		httpClient:     proxy.LambdaAPIClient(),
+		baseURL:        fmt.Sprintf("http://%s/%s/telemetry", os.Getenv("AWS_LAMBDA_RUNTIME_API"), apiVersion),
+		schemaVersions: supportedSchemaVersions,
+	}
+	if pinned, ok := os.LookupEnv(schemaVersionEnvKey); ok && pinned != "" {
+		c.logger.Info("Using Telemetry API schema version from environment", zap.String("schemaVersion", pinned))
+		c.schemaVersions = []SchemaVersion{SchemaVersion(pinned)}
 	}
+	return c
+}
+
+// SchemaVersion returns the schema version accepted by the Telemetry API on the last successful Subscribe.
+func (c *Client) SchemaVersion() SchemaVersion {
+	return c.schemaVersion
 }
 
+// Subscribe registers the listener with the Telemetry API. Unless a schema version has been pinned through
+// the environment, the newest supported schema version is tried first, falling back to older versions when
+// the runtime rejects the request.
 func (c *Client) Subscribe(ctx context.Context, extensionID string, listenerURI string) (string, error) {
+	var err error
+	for i, version := range c.schemaVersions {
+		var body string
+		body, err = c.subscribe(ctx, extensionID, listenerURI, version)
+		recordSubscription(ctx, err)
+		if err == nil {
+			c.schemaVersion = version
+			return body, nil
+		}
+		if !errors.Is(err, errUnsupportedSchema) || i == len(c.schemaVersions)-1 {
+			break
+		}
+		c.logger.Warn("Telemetry API rejected schema version, falling back", zap.String("schemaVersion", string(version)), zap.Error(err))
+	}
+	return "", err
+}
+
+func (c *Client) subscribe(ctx context.Context, extensionID string, listenerURI string, schemaVersion SchemaVersion) (string, error) {
 	eventTypes := []EventType{
 		Platform,
 		// Function,
@@ -67,7 +113,7 @@ func (c *Client) Subscribe(ctx context.Context, extensionID string, listenerURI
 
 	data, err := json.Marshal(
 		&SubscribeRequest{
-			SchemaVersion: SchemaVersionLatest,
+			SchemaVersion: schemaVersion,
 			EventTypes:    eventTypes,
 			BufferingCfg:  bufferingConfig,
 			Destination:   destination,
@@ -80,7 +126,7 @@ func (c *Client) Subscribe(ctx context.Context, extensionID string, listenerURI
 	headers := make(map[string]string)
 	headers[lambdaAgentIdentifierHeaderKey] = extensionID
 
-	c.logger.Info("Subscribing", zap.String("baseURL", c.baseURL))
+	c.logger.Info("Subscribing", zap.String("baseURL", c.baseURL), zap.String("schemaVersion", string(schemaVersion)))
 	resp, err := httpPutWithHeaders(ctx, c.httpClient, c.baseURL, data, headers)
 	if err != nil {
 		c.logger.Error("Subscription failed", zap.Error(err))
@@ -97,6 +143,9 @@ func (c *Client) Subscribe(ctx context.Context, extensionID string, listenerURI
 			return "", fmt.Errorf("request to %s failed: %d[%s]: %w", c.baseURL, resp.StatusCode, resp.Status, err)
 		}
 
+		if resp.StatusCode == http.StatusBadRequest {
+			return "", fmt.Errorf("request to %s failed: %d[%s] %s: %w", c.baseURL, resp.StatusCode, resp.Status, string(body), errUnsupportedSchema)
+		}
 		return "", fmt.Errorf("request to %s failed: %d[%s] %s", c.baseURL, resp.StatusCode, resp.Status, string(body))
 	}
 
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryapi
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/telemetryapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+func TestSubscribeSchemaNegotiation(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		pinned   string
+		accepted map[SchemaVersion]bool
+		expected SchemaVersion
+		tried    []SchemaVersion
+		wantErr  bool
+	}{
+		{
+			name:     "latest accepted",
+			accepted: map[SchemaVersion]bool{SchemaVersion20221213: true, SchemaVersion20220701: true},
+			expected: SchemaVersion20221213,
+			tried:    []SchemaVersion{SchemaVersion20221213},
+		},
+		{
+			name:     "falls back to older version",
+			accepted: map[SchemaVersion]bool{SchemaVersion20220701: true},
+			expected: SchemaVersion20220701,
+			tried:    []SchemaVersion{SchemaVersion20221213, SchemaVersion20220701},
+		},
+		{
+			name:    "no version accepted",
+			tried:   []SchemaVersion{SchemaVersion20221213, SchemaVersion20220701},
+			wantErr: true,
+		},
+		{
+			name:     "pinned version",
+			pinned:   SchemaVersion20220701,
+			accepted: map[SchemaVersion]bool{SchemaVersion20221213: true, SchemaVersion20220701: true},
+			expected: SchemaVersion20220701,
+			tried:    []SchemaVersion{SchemaVersion20220701},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := telemetryapitest.NewServer()
+			defer srv.Close()
+			srv.SetResponse(func(sub telemetryapitest.Subscription) int {
+				if !tc.accepted[SchemaVersion(sub.SchemaVersion)] {
+					return http.StatusBadRequest
+				}
+				return http.StatusOK
+			})
+
+			t.Setenv("AWS_LAMBDA_RUNTIME_API", srv.RuntimeAPI())
+			if tc.pinned != "" {
+				t.Setenv(schemaVersionEnvKey, tc.pinned)
+			}
+			c := NewClient(zap.NewNop())
+
+			_, err := c.Subscribe(context.Background(), "extensionID", "http://localhost/")
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, c.SchemaVersion())
+			var tried []SchemaVersion
+			for _, sub := range srv.Subscriptions() {
+				assert.Equal(t, "extensionID", sub.ExtensionID)
+				tried = append(tried, SchemaVersion(sub.SchemaVersion))
+			}
+			assert.Equal(t, tc.tried, tried)
+		})
+	}
+}
+
+func TestSubscribeRecordsMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	srv := telemetryapitest.NewServer()
+	defer srv.Close()
+	srv.SetResponse(func(sub telemetryapitest.Subscription) int {
+		if sub.SchemaVersion != SchemaVersion20220701 {
+			return http.StatusBadRequest
+		}
+		return http.StatusOK
+	})
+	t.Setenv("AWS_LAMBDA_RUNTIME_API", srv.RuntimeAPI())
+
+	_, err := NewClient(zap.NewNop()).Subscribe(context.Background(), "extensionID", "http://localhost/")
+	require.NoError(t, err)
+
+	rows, err := view.RetrieveData(statSubscriptions.Name())
+	require.NoError(t, err)
+	results := make(map[string]float64)
+	for _, row := range rows {
+		results[row.Tags[0].Value] = row.Data.(*view.SumData).Value
+	}
+	assert.Equal(t, map[string]float64{"success": 1, "failure": 1}, results)
+
+	rows, err = view.RetrieveData(statSubscribed.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1.0, rows[0].Data.(*view.LastValueData).Value)
+}
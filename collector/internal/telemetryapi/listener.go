@@ -18,31 +18,57 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/golang-collections/go-datastructures/queue"
+	"go.opencensus.io/stats"
 	"go.uber.org/zap"
 )
 
 const defaultListenerPort = "4323"
 const initialQueueSize = 5
 
+// putChunkSize is the number of decoded events handed to the queue at once, which bounds the events a request
+// holds while it is decoded.
+const putChunkSize = 64
+
+const (
+	platformInitRuntimeDone = "platform.initRuntimeDone"
+	platformInitReport      = "platform.initReport"
+	platformRuntimeDone     = "platform.runtimeDone"
+	platformReport          = "platform.report"
+)
+
+// itemsPool holds the slices used to hand the events of a request to the queue, so that functions logging
+// heavily do not allocate them anew for every request. The queue copies the items they hold.
+var itemsPool = sync.Pool{
+	New: func() interface{} {
+		return &[]interface{}{}
+	},
+}
+
 // Listener is used to listen to the Telemetry API
 type Listener struct {
 	httpServer *http.Server
 	logger     *zap.Logger
 	// queue is a synchronous queue and is used to put the received log events to be dispatched later
 	queue *queue.Queue
+	// runtimeDone holds the runtime duration of invocations whose platform.report has not been received yet
+	runtimeDone map[string]float64
+	// initEnd is when the runtime finished initializing, once a platform.initRuntimeDone or platform.initReport
+	// event has been received.
+	initEnd time.Time
 }
 
 func NewListener(logger *zap.Logger) *Listener {
 	return &Listener{
-		httpServer: nil,
-		logger:     logger.Named("telemetryAPI.Listener"),
-		queue:      queue.New(initialQueueSize),
+		httpServer:  nil,
+		logger:      logger.Named("telemetryAPI.Listener"),
+		queue:       queue.New(initialQueueSize),
+		runtimeDone: make(map[string]float64),
 	}
 }
 
@@ -83,28 +109,87 @@ func (s *Listener) Start() (string, error) {
 // receive extension logs. Otherwise, logging here will cause Telemetry API to send new logs for
 // the printed lines which may create an infinite loop.
 func (s *Listener) httpHandler(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		s.logger.Error("error reading body", zap.Error(err))
-		return
+	observed := time.Now()
+	defer func() {
+		stats.Record(r.Context(), statHandlerLatency.M(float64(time.Since(observed))/float64(time.Millisecond)))
+	}()
+
+	items := itemsPool.Get().(*[]interface{})
+	defer func() {
+		for i := range *items {
+			(*items)[i] = nil
+		}
+		*items = (*items)[:0]
+		itemsPool.Put(items)
+	}()
+
+	// Decode the events as they are read rather than reading the whole body first, and hand them to the queue
+	// in chunks of putChunkSize, so that the request holds at most one chunk of decoded events, while the queue
+	// takes its lock once per chunk rather than once per event. The events decoded before an error are kept.
+	count := 0
+	put := func(events []interface{}) {
+		count += len(events)
+		recordEventsReceived(r.Context(), events)
+		s.queue.Put(events...)
+	}
+	var err error
+	if *items, err = decodeEvents(json.NewDecoder(r.Body), observed, *items, put); err != nil {
+		stats.Record(r.Context(), statDecodeFailures.M(1))
+		s.logger.Error("error decoding body", zap.Error(err))
 	}
 
-	// Parse and put the log messages into the queue
-	var slice []Event
-	_ = json.Unmarshal(body, &slice)
+	s.logger.Debug("logEvents received", zap.Int("count", count), zap.Int64("queue_length", s.queue.Len()))
+}
 
-	for _, el := range slice {
-		s.queue.Put(el)
+// decodeEvents decodes the events of the JSON array read by dec, observed at observed, into chunk, and calls put
+// with every putChunkSize events, then with the remaining ones, including when it fails. It returns chunk emptied,
+// so that it can be reused.
+func decodeEvents(dec *json.Decoder, observed time.Time, chunk []interface{}, put func([]interface{})) ([]interface{}, error) {
+	flush := func() {
+		if len(chunk) > 0 {
+			put(chunk)
+		}
+		for i := range chunk {
+			chunk[i] = nil
+		}
+		chunk = chunk[:0]
 	}
+	err := decodeArray(dec, func(ev Event) {
+		ev.ObservedTime = observed
+		chunk = append(chunk, ev)
+		if len(chunk) == putChunkSize {
+			flush()
+		}
+	})
+	flush()
+	return chunk, err
+}
 
-	s.logger.Debug("logEvents received", zap.Int("count", len(slice)), zap.Int64("queue_length", s.queue.Len()))
-	slice = nil
+// decodeArray calls fn with each event of the JSON array read by dec.
+func decodeArray(dec *json.Decoder, fn func(Event)) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected an array of events, got %v", tok)
+	}
+	for dec.More() {
+		var ev Event
+		if err = dec.Decode(&ev); err != nil {
+			return err
+		}
+		fn(ev)
+	}
+	_, err = dec.Token()
+	return err
 }
 
 // Shutdown the HTTP server listening for logs
 func (s *Listener) Shutdown() {
 	if s.httpServer != nil {
-		ctx, _ := context.WithTimeout(context.Background(), 1*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
 		err := s.httpServer.Shutdown(ctx)
 		if err != nil {
 			s.logger.Error("Failed to shutdown HTTP server gracefully", zap.Error(err))
@@ -126,6 +211,7 @@ func (s *Listener) Wait(ctx context.Context, reqID string) error {
 				return fmt.Errorf("unable to get telemetry events from queue: %w", err)
 			}
 
+			done := false
 			for _, item := range items {
 				i, ok := item.(Event)
 				if !ok {
@@ -133,14 +219,75 @@ func (s *Listener) Wait(ctx context.Context, reqID string) error {
 					continue
 				}
 				s.logger.Debug("Event processed", zap.Any("event", i))
-				if i.Type != "platform.runtimeDone" {
-					continue
-				}
-
-				if i.Record["requestId"] == reqID {
-					return nil
+				switch i.Type {
+				case platformInitRuntimeDone, platformInitReport:
+					s.trackInitEnd(i)
+				case platformRuntimeDone:
+					s.trackRuntimeDone(i)
+					if i.Record["requestId"] == reqID {
+						done = true
+					}
+				case platformReport:
+					s.recordReport(ctx, i)
 				}
 			}
+			if done {
+				return nil
+			}
 		}
 	}
 }
+
+// trackInitEnd remembers the time of the first event reporting the end of the init phase. platform.initReport
+// is only used when platform.initRuntimeDone is missing, as it may be sent after the init phase.
+func (s *Listener) trackInitEnd(e Event) {
+	if !s.initEnd.IsZero() && e.Type == platformInitReport {
+		return
+	}
+	t, err := e.Timestamp()
+	if err != nil {
+		s.logger.Debug("invalid event time", zap.String("type", e.Type), zap.Error(err))
+		return
+	}
+	s.initEnd = t
+}
+
+// InitEnd returns when the runtime finished initializing, as reported by the events processed by Wait so far.
+func (s *Listener) InitEnd() (time.Time, bool) {
+	return s.initEnd, !s.initEnd.IsZero()
+}
+
+// trackRuntimeDone remembers how long the runtime took for an invocation so that the
+// extension overhead can be derived once the matching platform.report arrives.
+func (s *Listener) trackRuntimeDone(e Event) {
+	reqID, _ := e.Record["requestId"].(string)
+	if d, ok := recordMetric(e.Record, "durationMs"); ok && reqID != "" {
+		s.runtimeDone[reqID] = d
+	}
+}
+
+// recordReport emits the time spent in extensions after the runtime completed, which is the
+// part of the invocation duration reported by the platform that the runtime did not account for.
+func (s *Listener) recordReport(ctx context.Context, e Event) {
+	reqID, _ := e.Record["requestId"].(string)
+	runtimeDuration, ok := s.runtimeDone[reqID]
+	if !ok {
+		return
+	}
+	delete(s.runtimeDone, reqID)
+
+	invocationDuration, ok := recordMetric(e.Record, "durationMs")
+	if !ok || invocationDuration < runtimeDuration {
+		return
+	}
+	stats.Record(ctx, statPostRuntimeDuration.M(invocationDuration-runtimeDuration))
+}
+
+func recordMetric(record map[string]any, name string) (float64, bool) {
+	metrics, ok := record["metrics"].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	v, ok := metrics[name].(float64)
+	return v, ok
+}
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-collections/go-datastructures/queue"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/telemetryapitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.uber.org/zap"
+)
+
+func eventBatch(t testing.TB, n int) []byte {
+	events := make([]Event, n)
+	for i := range events {
+		events[i] = Event{
+			Time: "2022-10-12T00:00:00.000Z",
+			Type: "function",
+			Record: map[string]any{
+				"requestId": fmt.Sprintf("request-%d", i),
+				"message":   "a representative log line emitted by the function",
+			},
+		}
+	}
+	body, err := json.Marshal(events)
+	require.NoError(t, err)
+	return body
+}
+
+func TestHTTPHandlerQueuesEvents(t *testing.T) {
+	l := NewListener(zap.NewNop())
+	body := eventBatch(t, 3)
+
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	l.httpHandler(httptest.NewRecorder(), req)
+
+	require.EqualValues(t, 3, l.queue.Len())
+	items, err := l.queue.Get(3)
+	require.NoError(t, err)
+	for i, item := range items {
+		ev, ok := item.(Event)
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("request-%d", i), ev.Record["requestId"])
+		assert.False(t, ev.ObservedTime.IsZero())
+	}
+}
+
+func TestHTTPHandlerDoesNotShareRecordsBetweenRequests(t *testing.T) {
+	l := NewListener(zap.NewNop())
+	first := `[{"type":"function","record":{"requestId":"1","message":"first"}}]`
+	second := `[{"type":"function","record":{"requestId":"2"}}]`
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(first)))
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(second)))
+
+	items, err := l.queue.Get(2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, map[string]any{"requestId": "1", "message": "first"}, items[0].(Event).Record)
+	assert.Equal(t, map[string]any{"requestId": "2"}, items[1].(Event).Record)
+}
+
+func TestHTTPHandlerKeepsEventsDecodedBeforeAnError(t *testing.T) {
+	l := NewListener(zap.NewNop())
+	body := `[{"type":"function","record":{"requestId":"1"}},{"type":`
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+
+	require.EqualValues(t, 1, l.queue.Len())
+	items, err := l.queue.Get(1)
+	require.NoError(t, err)
+	assert.Equal(t, "1", items[0].(Event).Record["requestId"])
+}
+
+func TestDecodeEventsInChunks(t *testing.T) {
+	var chunks []int
+	dec := json.NewDecoder(bytes.NewReader(eventBatch(t, 2*putChunkSize+1)))
+	chunk, err := decodeEvents(dec, time.Now(), nil, func(events []interface{}) {
+		chunks = append(chunks, len(events))
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int{putChunkSize, putChunkSize, 1}, chunks)
+	assert.Empty(t, chunk)
+
+	l := NewListener(zap.NewNop())
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(eventBatch(t, 2*putChunkSize+1))))
+	assert.EqualValues(t, 2*putChunkSize+1, l.queue.Len())
+}
+
+func BenchmarkHTTPHandler(b *testing.B) {
+	l := NewListener(zap.NewNop())
+	body := eventBatch(b, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		l.httpHandler(httptest.NewRecorder(), req)
+		b.StopTimer()
+		l.queue = queue.New(initialQueueSize)
+		b.StartTimer()
+	}
+}
+
+func TestWaitRecordsPostRuntimeDuration(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	l := NewListener(zap.NewNop())
+	l.queue.Put(
+		Event{Type: platformRuntimeDone, Record: map[string]any{"requestId": "1", "metrics": map[string]any{"durationMs": 20.0}}},
+		Event{Type: platformReport, Record: map[string]any{"requestId": "1", "metrics": map[string]any{"durationMs": 27.5}}},
+		Event{Type: platformRuntimeDone, Record: map[string]any{"requestId": "2", "metrics": map[string]any{"durationMs": 10.0}}},
+	)
+
+	require.NoError(t, l.Wait(context.Background(), "2"))
+
+	rows, err := view.RetrieveData(statPostRuntimeDuration.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	dist, ok := rows[0].Data.(*view.DistributionData)
+	require.True(t, ok)
+	assert.EqualValues(t, 1, dist.Count)
+	assert.Equal(t, 7.5, dist.Mean)
+	assert.Contains(t, l.runtimeDone, "2")
+	assert.NotContains(t, l.runtimeDone, "1")
+}
+
+func TestWaitTracksInitEnd(t *testing.T) {
+	l := NewListener(zap.NewNop())
+	_, ok := l.InitEnd()
+	assert.False(t, ok)
+
+	l.queue.Put(
+		Event{Type: platformInitRuntimeDone, Time: "2022-12-01T10:00:00.250Z", Record: map[string]any{"status": "success"}},
+		Event{Type: platformInitReport, Time: "2022-12-01T10:00:00.300Z", Record: map[string]any{}},
+		Event{Type: platformRuntimeDone, Record: map[string]any{"requestId": "1"}},
+	)
+	require.NoError(t, l.Wait(context.Background(), "1"))
+
+	end, ok := l.InitEnd()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2022, 12, 1, 10, 0, 0, 250e6, time.UTC), end)
+}
+
+func TestWaitFallsBackToInitReport(t *testing.T) {
+	l := NewListener(zap.NewNop())
+	l.queue.Put(
+		Event{Type: platformInitReport, Time: "2022-12-01T10:00:00.300Z", Record: map[string]any{}},
+		Event{Type: platformRuntimeDone, Record: map[string]any{"requestId": "1"}},
+	)
+	require.NoError(t, l.Wait(context.Background(), "1"))
+
+	end, ok := l.InitEnd()
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2022, 12, 1, 10, 0, 0, 300e6, time.UTC), end)
+}
+
+func TestListenerReceivesReplayedEvents(t *testing.T) {
+	srv := telemetryapitest.NewServer()
+	defer srv.Close()
+	t.Setenv("AWS_LAMBDA_RUNTIME_API", srv.RuntimeAPI())
+
+	l := NewListener(zap.NewNop())
+	dest := httptest.NewServer(http.HandlerFunc(l.httpHandler))
+	defer dest.Close()
+
+	_, err := NewClient(zap.NewNop()).Subscribe(context.Background(), "extensionID", dest.URL)
+	require.NoError(t, err)
+
+	batches, err := telemetryapitest.LoadBatches(filepath.Join("testdata", "invocation.jsonl"))
+	require.NoError(t, err)
+	require.NoError(t, srv.Replay(context.Background(), batches, time.Millisecond))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, l.Wait(ctx, "6d68ca91-49c9-448d-89b8-7ca3e6dc66aa"))
+}
+
+func TestHTTPHandlerRecordsMetrics(t *testing.T) {
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	l := NewListener(zap.NewNop())
+	body := `[{"type":"platform.start","record":{}},{"type":"platform.start","record":{}},{"type":"platform.runtimeDone","record":{}}]`
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body)))
+	l.httpHandler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", strings.NewReader("not json")))
+
+	rows, err := view.RetrieveData(statEventsReceived.Name())
+	require.NoError(t, err)
+	received := make(map[string]int64)
+	for _, row := range rows {
+		received[row.Tags[0].Value] = int64(row.Data.(*view.SumData).Value)
+	}
+	assert.Equal(t, map[string]int64{"platform.start": 2, "platform.runtimeDone": 1}, received)
+
+	rows, err = view.RetrieveData(statDecodeFailures.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, 1.0, rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(statHandlerLatency.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.EqualValues(t, 2, rows[0].Data.(*view.DistributionData).Count)
+}
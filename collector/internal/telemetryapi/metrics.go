@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryapi
+
+import (
+	"context"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+const metricPrefix = "lambda_telemetryapi_"
+
+var (
+	eventTypeKey = tag.MustNewKey("type")
+	resultKey    = tag.MustNewKey("result")
+
+	statPostRuntimeDuration = stats.Float64(metricPrefix+"post_runtime_extensions_duration", "Time spent by extensions after the runtime finished an invocation", stats.UnitMilliseconds)
+	statSubscriptions       = stats.Int64(metricPrefix+"subscriptions", "Number of subscribe requests made to the Telemetry API", stats.UnitDimensionless)
+	statSubscribed          = stats.Int64(metricPrefix+"subscribed", "Whether the last subscribe request succeeded (1) or not (0)", stats.UnitDimensionless)
+	statEventsReceived      = stats.Int64(metricPrefix+"events_received", "Number of events received from the Telemetry API", stats.UnitDimensionless)
+	statHandlerLatency      = stats.Float64(metricPrefix+"handler_latency", "Time spent handling a batch posted by the Telemetry API", stats.UnitMilliseconds)
+	statDecodeFailures      = stats.Int64(metricPrefix+"decode_failures", "Number of batches from the Telemetry API that could not be decoded", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metrics views emitted while processing Telemetry API events.
+// They are exposed through the collector's own telemetry once registered with view.Register.
+func MetricViews() []*view.View {
+	distributionPostRuntimeDurationView := &view.View{
+		Name:        statPostRuntimeDuration.Name(),
+		Measure:     statPostRuntimeDuration,
+		Description: statPostRuntimeDuration.Description(),
+		Aggregation: view.Distribution(1, 2, 5, 10, 25, 50, 75, 100, 250, 500, 750, 1000, 2000, 5000),
+	}
+
+	countSubscriptionsView := &view.View{
+		Name:        statSubscriptions.Name(),
+		Measure:     statSubscriptions,
+		Description: statSubscriptions.Description(),
+		TagKeys:     []tag.Key{resultKey},
+		Aggregation: view.Sum(),
+	}
+
+	lastValueSubscribedView := &view.View{
+		Name:        statSubscribed.Name(),
+		Measure:     statSubscribed,
+		Description: statSubscribed.Description(),
+		Aggregation: view.LastValue(),
+	}
+
+	countEventsReceivedView := &view.View{
+		Name:        statEventsReceived.Name(),
+		Measure:     statEventsReceived,
+		Description: statEventsReceived.Description(),
+		TagKeys:     []tag.Key{eventTypeKey},
+		Aggregation: view.Sum(),
+	}
+
+	distributionHandlerLatencyView := &view.View{
+		Name:        statHandlerLatency.Name(),
+		Measure:     statHandlerLatency,
+		Description: statHandlerLatency.Description(),
+		Aggregation: view.Distribution(0.1, 0.25, 0.5, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000),
+	}
+
+	countDecodeFailuresView := &view.View{
+		Name:        statDecodeFailures.Name(),
+		Measure:     statDecodeFailures,
+		Description: statDecodeFailures.Description(),
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		distributionPostRuntimeDurationView,
+		countSubscriptionsView,
+		lastValueSubscribedView,
+		countEventsReceivedView,
+		distributionHandlerLatencyView,
+		countDecodeFailuresView,
+	}
+}
+
+func recordSubscription(ctx context.Context, err error) {
+	result, subscribed := "success", int64(1)
+	if err != nil {
+		result, subscribed = "failure", 0
+	}
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(resultKey, result)}, statSubscriptions.M(1))
+	stats.Record(ctx, statSubscribed.M(subscribed))
+}
+
+func recordEventsReceived(ctx context.Context, events []interface{}) {
+	counts := make(map[string]int64)
+	for _, item := range events {
+		if ev, ok := item.(Event); ok {
+			counts[ev.Type]++
+		}
+	}
+	for eventType, count := range counts {
+		_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(eventTypeKey, eventType)}, statEventsReceived.M(count))
+	}
+}
@@ -14,6 +14,8 @@
 
 package telemetryapi
 
+import "time"
+
 // EventType represents the type of log events in Lambda
 type EventType string
 
@@ -86,4 +88,11 @@ type Event struct {
 	Time   string         `json:"time"`
 	Type   string         `json:"type"`
 	Record map[string]any `json:"record"`
+	// ObservedTime is when the listener received the event. It is not part of the Telemetry API payload.
+	ObservedTime time.Time `json:"-"`
+}
+
+// Timestamp returns the platform-provided time of the event, parsed with nanosecond precision.
+func (e Event) Timestamp() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, e.Time)
 }
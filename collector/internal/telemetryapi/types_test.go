@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package telemetryapi
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventTimestamp(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		payload  string
+		expected time.Time
+	}{
+		{
+			name:     "platform.start",
+			payload:  `{"time":"2022-10-12T00:00:00.000Z","type":"platform.start","record":{"requestId":"6f7f0961f83442118a7af6fe80b88d56"}}`,
+			expected: time.Date(2022, 10, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "platform.runtimeDone",
+			payload:  `{"time":"2022-10-12T00:00:00.123Z","type":"platform.runtimeDone","record":{"requestId":"6f7f0961f83442118a7af6fe80b88d56","status":"success"}}`,
+			expected: time.Date(2022, 10, 12, 0, 0, 0, 123000000, time.UTC),
+		},
+		{
+			name:     "platform.report",
+			payload:  `{"time":"2022-10-12T00:00:00.123456Z","type":"platform.report","record":{"requestId":"6f7f0961f83442118a7af6fe80b88d56"}}`,
+			expected: time.Date(2022, 10, 12, 0, 0, 0, 123456000, time.UTC),
+		},
+		{
+			name:     "function",
+			payload:  `{"time":"2022-10-12T00:03:50.000123456Z","type":"function","record":"[INFO] Hello world, I am a function!"}`,
+			expected: time.Date(2022, 10, 12, 0, 3, 50, 123456, time.UTC),
+		},
+		{
+			name:     "extension",
+			payload:  `{"time":"2022-10-12T02:03:50.123456789+02:00","type":"extension","record":"[INFO] Hello world, I am an extension!"}`,
+			expected: time.Date(2022, 10, 12, 0, 3, 50, 123456789, time.UTC),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ev Event
+			// function and extension records are plain strings, which only the time field needs to survive.
+			_ = json.Unmarshal([]byte(tc.payload), &ev)
+
+			ts, err := ev.Timestamp()
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ts.UTC())
+		})
+	}
+}
+
+func TestEventTimestampInvalid(t *testing.T) {
+	_, err := Event{Time: "not a time"}.Timestamp()
+	assert.Error(t, err)
+}
@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+)
+
+const (
+	envGoMaxProcs = "GOMAXPROCS"
+
+	// memoryPerCPU is the memory, in MB, for which Lambda allocates the equivalent of one vCPU.
+	memoryPerCPU = 1769
+)
+
+// SetMaxProcs sets GOMAXPROCS to the CPU share Lambda allocates to the function, and returns it. Below
+// 1769 MB the function gets a fraction of a vCPU while the environment reports several of them: running
+// as many threads only makes them contend for that fraction. It returns zero, leaving GOMAXPROCS as is,
+// if GOMAXPROCS is set or if the memory of the function is unknown.
+func SetMaxProcs() (int, error) {
+	if os.Getenv(envGoMaxProcs) != "" {
+		return 0, nil
+	}
+	memory := os.Getenv(envFunctionMemory)
+	if memory == "" {
+		return 0, nil
+	}
+	procs, err := maxProcs(memory, runtime.NumCPU())
+	if err != nil {
+		return 0, err
+	}
+	runtime.GOMAXPROCS(procs)
+	return procs, nil
+}
+
+// maxProcs returns the number of whole vCPUs allocated to a function with memory MB, between one and the
+// numCPU reported by the environment.
+func maxProcs(memory string, numCPU int) (int, error) {
+	memoryMB, err := strconv.Atoi(memory)
+	if err != nil || memoryMB <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", envFunctionMemory, memory)
+	}
+	procs := memoryMB / memoryPerCPU
+	if procs < 1 {
+		procs = 1
+	}
+	if procs > numCPU {
+		procs = numCPU
+	}
+	return procs, nil
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxProcs(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		memory   string
+		numCPU   int
+		expected int
+		err      bool
+	}{
+		{name: "fraction of a vCPU", memory: "128", numCPU: 2, expected: 1},
+		{name: "one vCPU", memory: "1769", numCPU: 2, expected: 1},
+		{name: "between vCPUs", memory: "5000", numCPU: 3, expected: 2},
+		{name: "capped by the environment", memory: "10240", numCPU: 2, expected: 2},
+		{name: "invalid memory", memory: "lots", numCPU: 2, err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			procs, err := maxProcs(tc.memory, tc.numCPU)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, procs)
+		})
+	}
+}
+
+func TestSetMaxProcs(t *testing.T) {
+	previous := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(previous)
+
+	t.Setenv(envFunctionMemory, "128")
+	procs, err := SetMaxProcs()
+	require.NoError(t, err)
+	assert.Equal(t, 1, procs)
+	assert.Equal(t, 1, runtime.GOMAXPROCS(0))
+
+	t.Setenv(envGoMaxProcs, "4")
+	procs, err = SetMaxProcs()
+	require.NoError(t, err)
+	assert.Zero(t, procs)
+}
@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tuning adapts the Go runtime to the resources of the Lambda execution environment.
+package tuning // import "github.com/open-telemetry/opentelemetry-lambda/collector/internal/tuning"
+
+import (
+	"fmt"
+	"os"
+	"runtime/debug"
+	"strconv"
+)
+
+const (
+	// EnvMemoryReserve is the environment variable holding the memory, in MiB, left to the function's runtime
+	// when the memory limit of the extension is derived from the memory of the function. It defaults to half
+	// of the function's memory.
+	EnvMemoryReserve = "OPENTELEMETRY_EXTENSION_MEMORY_RESERVE_MIB"
+
+	envFunctionMemory = "AWS_LAMBDA_FUNCTION_MEMORY_SIZE"
+	envGoMemLimit     = "GOMEMLIMIT"
+
+	mib = 1 << 20
+)
+
+// SetMemoryLimit sets the soft memory limit of the Go runtime to the memory of the function minus the
+// reserve for its runtime, so that the garbage collector runs before the extension starves the function
+// rather than when the heap has doubled. It returns the limit set, or zero if GOMEMLIMIT is set, in which
+// case the runtime already applies it, or if the memory of the function is unknown.
+func SetMemoryLimit() (int64, error) {
+	if os.Getenv(envGoMemLimit) != "" {
+		return 0, nil
+	}
+	memory := os.Getenv(envFunctionMemory)
+	if memory == "" {
+		return 0, nil
+	}
+	limit, err := memoryLimit(memory, os.Getenv(EnvMemoryReserve))
+	if err != nil {
+		return 0, err
+	}
+	debug.SetMemoryLimit(limit)
+	return limit, nil
+}
+
+// memoryLimit returns the memory limit, in bytes, for a function with memory MiB of which reserve MiB are
+// left to its runtime.
+func memoryLimit(memory, reserve string) (int64, error) {
+	memoryMiB, err := strconv.ParseInt(memory, 10, 64)
+	if err != nil || memoryMiB <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", envFunctionMemory, memory)
+	}
+	reserveMiB := memoryMiB / 2
+	if reserve != "" {
+		reserveMiB, err = strconv.ParseInt(reserve, 10, 64)
+		if err != nil || reserveMiB < 0 {
+			return 0, fmt.Errorf("invalid %s %q", EnvMemoryReserve, reserve)
+		}
+	}
+	if reserveMiB >= memoryMiB {
+		return 0, fmt.Errorf("%s of %d MiB leaves no memory to the extension out of %d MiB", EnvMemoryReserve, reserveMiB, memoryMiB)
+	}
+	return (memoryMiB - reserveMiB) * mib, nil
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tuning
+
+import (
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimit(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		memory   string
+		reserve  string
+		expected int64
+		err      bool
+	}{
+		{name: "default reserve", memory: "512", expected: 256 * mib},
+		{name: "configured reserve", memory: "512", reserve: "384", expected: 128 * mib},
+		{name: "no reserve", memory: "128", reserve: "0", expected: 128 * mib},
+		{name: "reserve exceeds memory", memory: "128", reserve: "128", err: true},
+		{name: "invalid reserve", memory: "128", reserve: "a lot", err: true},
+		{name: "invalid memory", memory: "-1", err: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			limit, err := memoryLimit(tc.memory, tc.reserve)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestSetMemoryLimit(t *testing.T) {
+	previous := debug.SetMemoryLimit(-1)
+	defer debug.SetMemoryLimit(previous)
+
+	t.Setenv(envFunctionMemory, "1024")
+	t.Setenv(EnvMemoryReserve, "768")
+	limit, err := SetMemoryLimit()
+	require.NoError(t, err)
+	assert.EqualValues(t, 256*mib, limit)
+	assert.EqualValues(t, 256*mib, debug.SetMemoryLimit(-1))
+
+	t.Setenv(envGoMemLimit, "100MiB")
+	limit, err = SetMemoryLimit()
+	require.NoError(t, err)
+	assert.Zero(t, limit)
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdacomponents
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+)
+
+// Factories of the components built into the layer. Every component is registered by its own file,
+// named after the component and only built with its build tag, e.g. -tags lambdacomponents.exporter.kafka.
+// The components of the default layer are also built without tags, unless lambdacomponents.custom is set,
+// in which case the layer only contains the components selected by their tag or by a set tag such as
+// lambdacomponents.set.tracesminimal.
+var (
+	receiverFactories  []component.ReceiverFactory
+	exporterFactories  []component.ExporterFactory
+	processorFactories []component.ProcessorFactory
+	extensionFactories []component.ExtensionFactory
+)
+
+func Components() (component.Factories, error) {
+	var errs []error
+
+	receivers, err := component.MakeReceiverFactoryMap(receiverFactories...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	exporters, err := component.MakeExporterFactoryMap(exporterFactories...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	processors, err := component.MakeProcessorFactoryMap(processorFactories...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	extensions, err := component.MakeExtensionFactoryMap(extensionFactories...)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	factories := component.Factories{
+		Receivers:  receivers,
+		Exporters:  exporters,
+		Processors: processors,
+		Extensions: extensions,
+	}
+
+	return factories, multierr.Combine(errs...)
+}
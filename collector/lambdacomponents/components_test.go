@@ -0,0 +1,51 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !lambdacomponents.custom
+
+package lambdacomponents
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"google.golang.org/grpc/encoding"
+)
+
+func TestDefaultComponents(t *testing.T) {
+	factories, err := Components()
+	require.NoError(t, err)
+
+	for _, typ := range []component.Type{"coldstart", "invocation", "otlp"} {
+		assert.Contains(t, factories.Receivers, typ)
+	}
+	for _, typ := range []component.Type{"awsemf", "awss3", "awsxray", "file", "logging", "otlp", "otlphttp", "prometheusremotewrite"} {
+		assert.Contains(t, factories.Exporters, typ)
+	}
+	for _, typ := range []component.Type{"attributes", "filter", "invocationbatch", "lambdainvocation", "memory_limiter", "probabilistic_sampler", "redaction", "resource", "span", "transform"} {
+		assert.Contains(t, factories.Processors, typ)
+	}
+	for _, typ := range []component.Type{"basicauth", "bearertokenauth", "headers_setter", "secretsmanager_auth", "sigv4auth", "tmp_storage"} {
+		assert.Contains(t, factories.Extensions, typ)
+	}
+}
+
+func TestGRPCCompressors(t *testing.T) {
+	// The compressors are registered by configgrpc, which the otlp receiver and exporter are built with.
+	for _, name := range []string{"gzip", "snappy", "zstd"} {
+		assert.NotNil(t, encoding.GetCompressor(name), name)
+	}
+}
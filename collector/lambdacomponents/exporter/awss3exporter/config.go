@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter/awss3exporter"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	partitionHour   = "hour"
+	partitionMinute = "minute"
+
+	marshalerOTLPJSON  = "otlp_json"
+	marshalerOTLPProto = "otlp_proto"
+)
+
+// S3UploaderConfig describes where objects are written.
+type S3UploaderConfig struct {
+	Region   string `mapstructure:"region"`
+	S3Bucket string `mapstructure:"s3_bucket"`
+	// S3Prefix is prepended to every object key.
+	S3Prefix string `mapstructure:"s3_prefix"`
+	// S3Partition is the granularity of the time partition of the keys: hour or minute.
+	S3Partition string `mapstructure:"s3_partition"`
+	// FilePrefix is prepended to the name of every object.
+	FilePrefix string `mapstructure:"file_prefix"`
+	// Endpoint overrides the S3 endpoint, e.g. for S3 compatible storage.
+	Endpoint         string `mapstructure:"endpoint"`
+	S3ForcePathStyle bool   `mapstructure:"s3_force_path_style"`
+}
+
+// Config defines configuration for the awss3 exporter.
+type Config struct {
+	config.ExporterSettings        `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	S3Uploader S3UploaderConfig `mapstructure:"s3uploader"`
+	// MarshalerName is the encoding of the objects: otlp_json or otlp_proto.
+	MarshalerName string `mapstructure:"marshaler"`
+}
+
+var _ component.ExporterConfig = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.S3Uploader.S3Bucket == "" {
+		return errors.New("s3uploader.s3_bucket is required")
+	}
+	switch cfg.S3Uploader.S3Partition {
+	case partitionHour, partitionMinute:
+	default:
+		return fmt.Errorf("s3uploader.s3_partition must be %q or %q, got %q", partitionHour, partitionMinute, cfg.S3Uploader.S3Partition)
+	}
+	switch cfg.MarshalerName {
+	case marshalerOTLPJSON, marshalerOTLPProto:
+	default:
+		return fmt.Errorf("marshaler must be %q or %q, got %q", marshalerOTLPJSON, marshalerOTLPProto, cfg.MarshalerName)
+	}
+	return nil
+}
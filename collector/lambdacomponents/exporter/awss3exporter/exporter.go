@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/exporter/awss3exporter"
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// uploader writes an object to the bucket.
+type uploader interface {
+	upload(ctx context.Context, key string, body []byte) error
+}
+
+type s3Uploader struct {
+	client *s3.S3
+	bucket string
+}
+
+func (u *s3Uploader) upload(ctx context.Context, key string, body []byte) error {
+	_, err := u.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(u.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	return err
+}
+
+// s3Exporter writes each batch to its own object, keyed by function and time partition.
+type s3Exporter struct {
+	cfg      *Config
+	uploader uploader
+	function string
+	now      func() time.Time
+
+	tracesMarshaler  ptrace.Marshaler
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+	extension        string
+}
+
+func newS3Exporter(cfg *Config) *s3Exporter {
+	e := &s3Exporter{
+		cfg:      cfg,
+		function: os.Getenv("AWS_LAMBDA_FUNCTION_NAME"),
+		now:      time.Now,
+	}
+	if cfg.MarshalerName == marshalerOTLPProto {
+		e.tracesMarshaler, e.metricsMarshaler, e.logsMarshaler = &ptrace.ProtoMarshaler{}, &pmetric.ProtoMarshaler{}, &plog.ProtoMarshaler{}
+		e.extension = "binpb"
+	} else {
+		e.tracesMarshaler, e.metricsMarshaler, e.logsMarshaler = &ptrace.JSONMarshaler{}, &pmetric.JSONMarshaler{}, &plog.JSONMarshaler{}
+		e.extension = "json"
+	}
+	return e
+}
+
+func (e *s3Exporter) start(context.Context, component.Host) error {
+	awsCfg := aws.NewConfig().WithS3ForcePathStyle(e.cfg.S3Uploader.S3ForcePathStyle)
+	if e.cfg.S3Uploader.Region != "" {
+		awsCfg = awsCfg.WithRegion(e.cfg.S3Uploader.Region)
+	}
+	if e.cfg.S3Uploader.Endpoint != "" {
+		awsCfg = awsCfg.WithEndpoint(e.cfg.S3Uploader.Endpoint)
+	}
+	sess, err := session.NewSession(awsCfg)
+	if err != nil {
+		return err
+	}
+	e.uploader = &s3Uploader{client: s3.New(sess), bucket: e.cfg.S3Uploader.S3Bucket}
+	return nil
+}
+
+// key returns <prefix>/<function>/year=YYYY/month=MM/day=DD/hour=HH[/minute=mm]/<file_prefix><signal>_<uuid>.<ext>.
+func (e *s3Exporter) key(signal string) string {
+	layout := "year=2006/month=01/day=02/hour=15"
+	if e.cfg.S3Uploader.S3Partition == partitionMinute {
+		layout += "/minute=04"
+	}
+	name := e.cfg.S3Uploader.FilePrefix + signal + "_" + uuid.NewString() + "." + e.extension
+	return path.Join(e.cfg.S3Uploader.S3Prefix, e.function, e.now().UTC().Format(layout), name)
+}
+
+func (e *s3Exporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	body, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+	return e.uploader.upload(ctx, e.key("traces"), body)
+}
+
+func (e *s3Exporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	body, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return e.uploader.upload(ctx, e.key("metrics"), body)
+}
+
+func (e *s3Exporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	body, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return e.uploader.upload(ctx, e.key("logs"), body)
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package awss3exporter
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type memUploader map[string][]byte
+
+func (m memUploader) upload(_ context.Context, key string, body []byte) error {
+	m[key] = body
+	return nil
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.EqualError(t, cfg.Validate(), "s3uploader.s3_bucket is required")
+	cfg.S3Uploader.S3Bucket = "archive"
+	assert.NoError(t, cfg.Validate())
+	cfg.S3Uploader.S3Partition = "day"
+	assert.Error(t, cfg.Validate())
+	cfg.S3Uploader.S3Partition = partitionHour
+	cfg.MarshalerName = "csv"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestPushTraces(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "my-function")
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Uploader.S3Bucket = "archive"
+	cfg.S3Uploader.S3Prefix = "telemetry"
+	cfg.S3Uploader.FilePrefix = "lambda_"
+
+	e := newS3Exporter(cfg)
+	objects := memUploader{}
+	e.uploader = objects
+	e.now = func() time.Time { return time.Date(2022, 12, 13, 14, 15, 16, 0, time.UTC) }
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	require.NoError(t, e.pushTraces(context.Background(), td))
+
+	require.Len(t, objects, 1)
+	for key, body := range objects {
+		assert.Regexp(t, regexp.MustCompile(`^telemetry/my-function/year=2022/month=12/day=13/hour=14/minute=15/lambda_traces_[0-9a-f-]{36}\.json$`), key)
+		decoded, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(body)
+		require.NoError(t, err)
+		assert.Equal(t, 1, decoded.SpanCount())
+	}
+}
+
+func TestPushLogsProto(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.S3Uploader.S3Bucket = "archive"
+	cfg.S3Uploader.S3Partition = partitionHour
+	cfg.MarshalerName = marshalerOTLPProto
+
+	e := newS3Exporter(cfg)
+	objects := memUploader{}
+	e.uploader = objects
+	e.function = ""
+	e.now = func() time.Time { return time.Date(2022, 12, 13, 14, 15, 16, 0, time.UTC) }
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, e.pushLogs(context.Background(), ld))
+
+	require.Len(t, objects, 1)
+	for key := range objects {
+		assert.Regexp(t, regexp.MustCompile(`^year=2022/month=12/day=13/hour=14/logs_[0-9a-f-]{36}\.binpb$`), key)
+	}
+}
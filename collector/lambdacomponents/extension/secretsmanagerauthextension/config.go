@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsmanagerauthextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/secretsmanagerauthextension"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the secretsmanager_auth extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// SecretID is the name or ARN of the secret holding the credentials.
+	SecretID string `mapstructure:"secret_id"`
+	// Key selects a field of a secret holding a JSON object. The whole secret is used when it is empty.
+	Key string `mapstructure:"key"`
+	// Header is the header carrying the credentials.
+	Header string `mapstructure:"header"`
+	// Prefix is prepended to the secret in the header, e.g. "Bearer ".
+	Prefix string `mapstructure:"prefix"`
+	// Endpoint overrides the endpoint of Secrets Manager, e.g. with the one of an interface VPC endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+	// MinRefreshInterval is the minimum time between two fetches of the secret after a rejected request.
+	MinRefreshInterval time.Duration `mapstructure:"min_refresh_interval"`
+}
+
+var _ component.ExtensionConfig = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.SecretID == "" {
+		return errors.New("secret_id must be set")
+	}
+	if cfg.Header == "" {
+		return errors.New("header must be set")
+	}
+	if cfg.MinRefreshInterval < 0 {
+		return errors.New("min_refresh_interval must not be negative")
+	}
+	return nil
+}
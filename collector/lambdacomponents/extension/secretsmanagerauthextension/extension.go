@@ -0,0 +1,228 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsmanagerauthextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/secretsmanagerauthextension"
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/credentials"
+)
+
+// getter fetches the value of a secret.
+type getter interface {
+	GetSecret(ctx context.Context, id string) (string, error)
+}
+
+type secretsManager struct {
+	client *secretsmanager.SecretsManager
+}
+
+func (s *secretsManager) GetSecret(ctx context.Context, id string) (string, error) {
+	out, err := s.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{SecretId: aws.String(id)})
+	if err != nil {
+		return "", err
+	}
+	if out.SecretString != nil {
+		return *out.SecretString, nil
+	}
+	return string(out.SecretBinary), nil
+}
+
+// errGRPCUnsupported is returned to gRPC exporters, whose rejected requests can't refresh the secret.
+var errGRPCUnsupported = errors.New("secretsmanager_auth only supports HTTP exporters, as gRPC requests can't refresh a rejected secret")
+
+// secretsAuth adds a secret to the requests of the HTTP exporters. The secret is fetched once, when the extension
+// starts, and cached for the lifetime of the execution environment: it is only fetched again when the backend
+// rejects it, which is what happens once it has been rotated.
+type secretsAuth struct {
+	cfg    *Config
+	logger *zap.Logger
+	getter getter
+	now    func() time.Time
+
+	mu        sync.RWMutex
+	value     string
+	lastFetch time.Time
+	// refreshes lets concurrently rejected requests share a single fetch of the secret, which runs without mu held.
+	refreshes singleflight.Group
+}
+
+var _ configauth.ClientAuthenticator = (*secretsAuth)(nil)
+
+// newSecretsAuth returns the extension, fetching the secret from Secrets Manager if g is nil.
+func newSecretsAuth(logger *zap.Logger, cfg *Config, g getter) *secretsAuth {
+	return &secretsAuth{
+		cfg:    cfg,
+		logger: logger,
+		getter: g,
+		now:    time.Now,
+	}
+}
+
+// Start fetches the secret, so that a missing secret or permission fails the start of the collector.
+func (a *secretsAuth) Start(ctx context.Context, _ component.Host) error {
+	if a.getter == nil {
+		sess, err := session.NewSession(&aws.Config{Endpoint: aws.String(a.cfg.Endpoint)})
+		if err != nil {
+			return fmt.Errorf("failed to create an AWS session: %w", err)
+		}
+		a.getter = &secretsManager{client: secretsmanager.New(sess)}
+	}
+
+	value, err := a.fetch(ctx)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.value = value
+	a.lastFetch = a.now()
+	return nil
+}
+
+func (a *secretsAuth) Shutdown(context.Context) error {
+	return nil
+}
+
+func (a *secretsAuth) fetch(ctx context.Context) (string, error) {
+	secret, err := a.getter.GetSecret(ctx, a.cfg.SecretID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", a.cfg.SecretID, err)
+	}
+	if a.cfg.Key == "" {
+		return secret, nil
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object: %w", a.cfg.SecretID, err)
+	}
+	value, ok := fields[a.cfg.Key].(string)
+	if !ok {
+		return "", fmt.Errorf("secret %s has no string field %q", a.cfg.SecretID, a.cfg.Key)
+	}
+	return value, nil
+}
+
+func (a *secretsAuth) current() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.value
+}
+
+// refresh fetches the secret again after the backend rejected the rejected value, at most once per
+// min_refresh_interval. It returns the value to retry the request with, if there is a new one. Requests rejected
+// while the secret is fetched wait for that fetch rather than starting their own, and requests that are not
+// rejected keep using the current value meanwhile.
+func (a *secretsAuth) refresh(ctx context.Context, rejected string) (string, bool) {
+	v, _, _ := a.refreshes.Do(rejected, func() (interface{}, error) {
+		a.mu.Lock()
+		if a.value != rejected {
+			// Another request already refreshed it.
+			value := a.value
+			a.mu.Unlock()
+			return value, nil
+		}
+		if a.now().Sub(a.lastFetch) < a.cfg.MinRefreshInterval {
+			a.mu.Unlock()
+			return "", nil
+		}
+		a.lastFetch = a.now()
+		a.mu.Unlock()
+
+		value, err := a.fetch(ctx)
+		if err != nil {
+			a.logger.Warn("Failed to refresh rejected credentials", zap.Error(err))
+			return "", nil
+		}
+		if value == rejected {
+			a.logger.Warn("Credentials rejected by the backend are still the current value of the secret", zap.String("secret", a.cfg.SecretID))
+			return "", nil
+		}
+		a.logger.Info("Refreshed rejected credentials", zap.String("secret", a.cfg.SecretID))
+		a.mu.Lock()
+		a.value = value
+		a.mu.Unlock()
+		return value, nil
+	})
+	value := v.(string)
+	return value, value != ""
+}
+
+func (a *secretsAuth) headerValue(value string) string {
+	return a.cfg.Prefix + value
+}
+
+// RoundTripper returns a RoundTripper adding the secret to the requests, and retrying a rejected request once
+// with the refreshed secret.
+func (a *secretsAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
+	return &roundTripper{base: base, auth: a}, nil
+}
+
+// PerRPCCredentials fails: gRPC gives no access to the status of the response to credentials, so the secret could
+// not be refreshed once the backend rejects it.
+func (a *secretsAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
+	return nil, errGRPCUnsupported
+}
+
+type roundTripper struct {
+	base http.RoundTripper
+	auth *secretsAuth
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	value := rt.auth.current()
+	resp, err := rt.base.RoundTrip(rt.withCredentials(req, req.Body, value))
+	if err != nil || (resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden) {
+		return resp, err
+	}
+
+	value, ok := rt.auth.refresh(req.Context(), value)
+	if !ok {
+		return resp, nil
+	}
+	body := req.Body
+	if body != nil {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		if body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return rt.base.RoundTrip(rt.withCredentials(req, body, value))
+}
+
+func (rt *roundTripper) withCredentials(req *http.Request, body io.ReadCloser, value string) *http.Request {
+	out := req.Clone(req.Context())
+	out.Body = body
+	out.Header.Set(rt.auth.cfg.Header, rt.auth.headerValue(value))
+	return out
+}
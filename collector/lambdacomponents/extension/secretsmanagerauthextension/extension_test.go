@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretsmanagerauthextension
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+type fakeGetter struct {
+	mu    sync.Mutex
+	value string
+	err   error
+	calls int
+}
+
+func (g *fakeGetter) GetSecret(context.Context, string) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls++
+	return g.value, g.err
+}
+
+func (g *fakeGetter) rotate(value string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value = value
+}
+
+func newTestAuth(t *testing.T, g getter, configure func(*Config)) *secretsAuth {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SecretID = "backend"
+	if configure != nil {
+		configure(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	return newSecretsAuth(zap.NewNop(), cfg, g)
+}
+
+// backend accepts the requests authenticated with the key it currently holds.
+func backend(t *testing.T, key *string, bodies *[]string) *httptest.Server {
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		if r.Header.Get("x-api-key") != *key {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestStartFetchesSecret(t *testing.T) {
+	g := &fakeGetter{value: `{"apiKey":"abc"}`}
+	a := newTestAuth(t, g, func(cfg *Config) {
+		cfg.Key = "apiKey"
+		cfg.Prefix = "Bearer "
+	})
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+
+	var header string
+	rt, err := a.RoundTripper(roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	}))
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(httptest.NewRequest(http.MethodGet, "https://otlp.example.com", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer abc", header)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestPerRPCCredentialsUnsupported(t *testing.T) {
+	a := newTestAuth(t, &fakeGetter{value: "abc"}, nil)
+	_, err := a.PerRPCCredentials()
+	assert.ErrorIs(t, err, errGRPCUnsupported)
+}
+
+// blockingGetter returns value once release is closed.
+type blockingGetter struct {
+	fakeGetter
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *blockingGetter) GetSecret(ctx context.Context, id string) (string, error) {
+	g.started <- struct{}{}
+	<-g.release
+	return g.fakeGetter.GetSecret(ctx, id)
+}
+
+func TestConcurrentRefreshesShareOneFetch(t *testing.T) {
+	g := &fakeGetter{value: "old"}
+	a := newTestAuth(t, g, func(cfg *Config) { cfg.MinRefreshInterval = 0 })
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+
+	blocking := &blockingGetter{fakeGetter: fakeGetter{value: "new"}, started: make(chan struct{}, 1), release: make(chan struct{})}
+	a.getter = blocking
+	var wg sync.WaitGroup
+	values := make([]string, 5)
+	for i := range values {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			values[i], _ = a.refresh(context.Background(), "old")
+		}(i)
+	}
+	<-blocking.started
+	assert.Equal(t, "old", a.current(), "not blocked by the fetch")
+	close(blocking.release)
+	wg.Wait()
+
+	assert.Equal(t, []string{"new", "new", "new", "new", "new"}, values)
+	assert.Equal(t, 1, blocking.calls)
+}
+
+func TestStartFails(t *testing.T) {
+	for name, g := range map[string]*fakeGetter{
+		"error":       {err: errors.New("access denied")},
+		"not JSON":    {value: "abc"},
+		"missing key": {value: `{"other":"abc"}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := newTestAuth(t, g, func(cfg *Config) { cfg.Key = "apiKey" })
+			assert.Error(t, a.Start(context.Background(), componenttest.NewNopHost()))
+		})
+	}
+}
+
+func TestRoundTripperRefreshesRejectedSecret(t *testing.T) {
+	key := "old"
+	var bodies []string
+	srv := backend(t, &key, &bodies)
+
+	g := &fakeGetter{value: "old"}
+	a := newTestAuth(t, g, func(cfg *Config) { cfg.Header = "x-api-key" })
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+	now := time.Now()
+	a.now = func() time.Time { return now }
+
+	rt, err := a.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	client := &http.Client{Transport: rt}
+	post := func() int {
+		resp, err := client.Post(srv.URL, "text/plain", strings.NewReader("payload"))
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, post())
+
+	// The key is rotated: the rejected request is retried once with the new value.
+	key = "new"
+	g.rotate("new")
+	now = now.Add(defaultMinRefreshInterval)
+	assert.Equal(t, http.StatusOK, post())
+	assert.Equal(t, []string{"payload", "payload", "payload"}, bodies)
+	assert.Equal(t, 2, g.calls)
+	assert.Equal(t, "new", a.current())
+
+	// Rejections don't fetch the secret more than once per min_refresh_interval.
+	key = "newer"
+	g.rotate("newer")
+	now = now.Add(time.Second)
+	assert.Equal(t, http.StatusForbidden, post())
+	assert.Equal(t, 2, g.calls)
+	now = now.Add(defaultMinRefreshInterval)
+	assert.Equal(t, http.StatusOK, post())
+	assert.Equal(t, 3, g.calls)
+}
+
+func TestRoundTripperKeepsSecretStillRejected(t *testing.T) {
+	key := "expected"
+	var bodies []string
+	srv := backend(t, &key, &bodies)
+
+	g := &fakeGetter{value: "wrong"}
+	a := newTestAuth(t, g, func(cfg *Config) {
+		cfg.Header = "x-api-key"
+		cfg.MinRefreshInterval = 0
+	})
+	require.NoError(t, a.Start(context.Background(), componenttest.NewNopHost()))
+
+	rt, err := a.RoundTripper(http.DefaultTransport)
+	require.NoError(t, err)
+	resp, err := (&http.Client{Transport: rt}).Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Len(t, bodies, 1)
+	assert.Equal(t, 2, g.calls)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.Error(t, cfg.Validate())
+	cfg.SecretID = "backend"
+	assert.NoError(t, cfg.Validate())
+	cfg.Header = ""
+	assert.Error(t, cfg.Validate())
+	cfg.Header = defaultHeader
+	cfg.MinRefreshInterval = -time.Second
+	assert.Error(t, cfg.Validate())
+}
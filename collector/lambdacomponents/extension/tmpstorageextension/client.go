@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpstorageextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/tmpstorageextension"
+
+import (
+	"context"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+type client struct {
+	storage *tmpStorage
+	dir     string
+}
+
+var _ storage.Client = (*client)(nil)
+
+func (c *client) Get(_ context.Context, key string) ([]byte, error) {
+	return c.storage.get(c.path(key))
+}
+
+func (c *client) Set(_ context.Context, key string, value []byte) error {
+	return c.storage.set(c.path(key), value)
+}
+
+func (c *client) Delete(_ context.Context, key string) error {
+	return c.storage.delete(c.path(key))
+}
+
+// Batch runs the operations in order and stops at the first failure.
+func (c *client) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		var err error
+		switch op.Type {
+		case storage.Get:
+			op.Value, err = c.Get(ctx, op.Key)
+		case storage.Set:
+			err = c.Set(ctx, op.Key, op.Value)
+		case storage.Delete:
+			err = c.Delete(ctx, op.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *client) Close(context.Context) error {
+	return nil
+}
+
+func (c *client) path(key string) string {
+	return filepath.Join(c.dir, fileName(key))
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpstorageextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/tmpstorageextension"
+
+import (
+	"errors"
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the tmp_storage extension.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Directory is where the data is stored. /tmp is the only writable location in the execution environment.
+	Directory string `mapstructure:"directory"`
+	// MaxSizeMiB is the maximum size of the data stored by all the clients of the extension.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+	// Encrypt encrypts the values with a key generated when the extension starts and only kept in memory.
+	Encrypt bool `mapstructure:"encrypt"`
+}
+
+var _ component.ExtensionConfig = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid.
+func (cfg *Config) Validate() error {
+	if !filepath.IsAbs(cfg.Directory) {
+		return errors.New("directory must be an absolute path")
+	}
+	if cfg.MaxSizeMiB <= 0 {
+		return errors.New("max_size_mib must be positive")
+	}
+	return nil
+}
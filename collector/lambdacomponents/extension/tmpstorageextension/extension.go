@@ -0,0 +1,254 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpstorageextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/tmpstorageextension"
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+// keyPrefix starts the names of the files storing the values.
+const keyPrefix = "key_"
+
+// ErrStorageFull is returned when storing a value would exceed max_size_mib.
+var ErrStorageFull = errors.New("tmp_storage is full")
+
+// tmpStorage stores every value in its own file, so that the data outlives a restart of the
+// extension within the execution environment, and caps the space taken from the ephemeral storage.
+type tmpStorage struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	mu      sync.Mutex
+	used    int64
+	maxSize int64
+	// aead encrypts the values when encrypt is enabled.
+	aead cipher.AEAD
+}
+
+var _ storage.Extension = (*tmpStorage)(nil)
+
+func newTmpStorage(logger *zap.Logger, cfg *Config) *tmpStorage {
+	return &tmpStorage{
+		cfg:     cfg,
+		logger:  logger,
+		maxSize: cfg.MaxSizeMiB << 20,
+	}
+}
+
+// Start creates the directory and accounts for the data left by a previous run of the extension.
+func (s *tmpStorage) Start(context.Context, component.Host) error {
+	if err := os.MkdirAll(s.cfg.Directory, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", s.cfg.Directory, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cfg.Encrypt {
+		return s.startEncrypted()
+	}
+	s.used = 0
+	err := filepath.WalkDir(s.cfg.Directory, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		s.used += info.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if s.used > 0 {
+		s.logger.Info("Reusing stored data", zap.String("directory", s.cfg.Directory), zap.Int64("bytes", s.used))
+	}
+	return nil
+}
+
+// startEncrypted generates the key of this run of the extension. The data left by a previous run was encrypted with
+// a key that is lost, so it is removed.
+func (s *tmpStorage) startEncrypted() error {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return fmt.Errorf("failed to generate the encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	if s.aead, err = cipher.NewGCM(block); err != nil {
+		return err
+	}
+
+	s.used = 0
+	removed := 0
+	err = filepath.WalkDir(s.cfg.Directory, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasPrefix(d.Name(), keyPrefix) {
+			return err
+		}
+		removed++
+		return os.Remove(path)
+	})
+	if err != nil {
+		return err
+	}
+	if removed > 0 {
+		s.logger.Info("Removed the data stored by a previous run", zap.String("directory", s.cfg.Directory), zap.Int("files", removed))
+	}
+	return nil
+}
+
+func (s *tmpStorage) Shutdown(context.Context) error {
+	return nil
+}
+
+// GetClient returns a client storing its data in a directory dedicated to the component and storage name.
+func (s *tmpStorage) GetClient(_ context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	parts := []string{kindString(kind), string(id.Type())}
+	if id.Name() != "" {
+		parts = append(parts, id.Name())
+	}
+	if name != "" {
+		parts = append(parts, name)
+	}
+	dir := filepath.Join(s.cfg.Directory, sanitize(strings.Join(parts, "_")))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	return &client{storage: s, dir: dir}, nil
+}
+
+func (s *tmpStorage) get(path string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil || s.aead == nil {
+		return value, err
+	}
+	return s.open(path, value)
+}
+
+// seal encrypts value, bound to the path of its file so that files can't be swapped, after a random nonce.
+func (s *tmpStorage) seal(path string, value []byte) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize(), s.aead.NonceSize()+len(value)+s.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, value, []byte(path)), nil
+}
+
+func (s *tmpStorage) open(path string, sealed []byte) ([]byte, error) {
+	if len(sealed) < s.aead.NonceSize() {
+		return nil, fmt.Errorf("failed to decrypt %s: too short", path)
+	}
+	nonce, ciphertext := sealed[:s.aead.NonceSize()], sealed[s.aead.NonceSize():]
+	value, err := s.aead.Open(nil, nonce, ciphertext, []byte(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", path, err)
+	}
+	return value, nil
+}
+
+func (s *tmpStorage) set(path string, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aead != nil {
+		var err error
+		if value, err = s.seal(path, value); err != nil {
+			return err
+		}
+	}
+	delta := int64(len(value)) - fileSize(path)
+	if s.used+delta > s.maxSize {
+		return ErrStorageFull
+	}
+
+	// Write to a temporary file first, so that a value is never partially written.
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, value, 0o600); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	s.used += delta
+	return nil
+}
+
+func (s *tmpStorage) delete(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	size := fileSize(path)
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	s.used -= size
+	return nil
+}
+
+func fileSize(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+func kindString(kind component.Kind) string {
+	switch kind {
+	case component.KindReceiver:
+		return "receiver"
+	case component.KindProcessor:
+		return "processor"
+	case component.KindExporter:
+		return "exporter"
+	case component.KindExtension:
+		return "extension"
+	default:
+		return "other"
+	}
+}
+
+// sanitize makes a component ID safe to use as a directory name.
+func sanitize(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", "..", "_").Replace(name)
+}
+
+// fileName encodes a key, which can contain any character, into a file name.
+func fileName(key string) string {
+	return keyPrefix + base64.RawURLEncoding.EncodeToString([]byte(key))
+}
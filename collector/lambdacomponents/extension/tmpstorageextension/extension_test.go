@@ -0,0 +1,156 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpstorageextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/zap"
+)
+
+func newTestStorage(t *testing.T, dir string, maxSizeMiB int64) *tmpStorage {
+	return newConfiguredStorage(t, dir, maxSizeMiB, false)
+}
+
+func newConfiguredStorage(t *testing.T, dir string, maxSizeMiB int64, encrypt bool) *tmpStorage {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Directory = dir
+	cfg.MaxSizeMiB = maxSizeMiB
+	cfg.Encrypt = encrypt
+	require.NoError(t, cfg.Validate())
+
+	s := newTmpStorage(zap.NewNop(), cfg)
+	require.NoError(t, s.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, s.Shutdown(context.Background())) })
+	return s
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t, t.TempDir(), 1)
+	c, err := s.GetClient(ctx, component.KindExporter, component.NewIDWithName("otlp", "backend"), "traces")
+	require.NoError(t, err)
+	defer c.Close(ctx)
+
+	value, err := c.Get(ctx, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, c.Set(ctx, "a/../key", []byte("value")))
+	value, err = c.Get(ctx, "a/../key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+
+	get := storage.GetOperation("a/../key")
+	require.NoError(t, c.Batch(ctx, storage.SetOperation("other", []byte("1")), get, storage.DeleteOperation("a/../key")))
+	assert.Equal(t, []byte("value"), get.Value)
+
+	value, err = c.Get(ctx, "a/../key")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	require.NoError(t, c.Delete(ctx, "missing"))
+	assert.EqualValues(t, 1, s.used)
+}
+
+func TestClientMaxSize(t *testing.T) {
+	ctx := context.Background()
+	s := newTestStorage(t, t.TempDir(), 1)
+	c, err := s.GetClient(ctx, component.KindExporter, component.NewID("otlp"), "")
+	require.NoError(t, err)
+
+	require.NoError(t, c.Set(ctx, "a", make([]byte, 1<<19)))
+	require.NoError(t, c.Set(ctx, "b", make([]byte, 1<<19)))
+	assert.ErrorIs(t, c.Set(ctx, "c", []byte("x")), ErrStorageFull)
+
+	// Overwriting a value only accounts for the difference.
+	require.NoError(t, c.Set(ctx, "b", make([]byte, 1<<18)))
+	require.NoError(t, c.Set(ctx, "c", []byte("x")))
+	assert.EqualValues(t, 1<<19+1<<18+1, s.used)
+}
+
+func TestDataOutlivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	id := component.NewID("otlp")
+
+	s := newTestStorage(t, dir, 1)
+	c, err := s.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "key", []byte("value")))
+
+	restarted := newTestStorage(t, dir, 1)
+	assert.EqualValues(t, 5, restarted.used)
+	c, err = restarted.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	value, err := c.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestEncryptedClient(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	id := component.NewID("otlp")
+
+	s := newConfiguredStorage(t, dir, 1, true)
+	c, err := s.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	require.NoError(t, c.Set(ctx, "a", []byte("sensitive telemetry")))
+	require.NoError(t, c.Set(ctx, "b", []byte("other telemetry")))
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("sensitive telemetry"), value)
+
+	path := c.(*client).path("a")
+	stored, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), "sensitive")
+	assert.EqualValues(t, len(stored), fileSize(path))
+
+	// A value moved to the file of another key is rejected.
+	require.NoError(t, os.Rename(path, c.(*client).path("b")))
+	_, err = c.Get(ctx, "b")
+	assert.Error(t, err)
+
+	// The key is lost on restart, so is the data.
+	restarted := newConfiguredStorage(t, dir, 1, true)
+	assert.EqualValues(t, 0, restarted.used)
+	c, err = restarted.GetClient(ctx, component.KindExporter, id, "")
+	require.NoError(t, err)
+	value, err = c.Get(ctx, "b")
+	require.NoError(t, err)
+	assert.Nil(t, value)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+	cfg.Directory = "tmp"
+	assert.Error(t, cfg.Validate())
+	cfg.Directory = defaultDirectory
+	cfg.MaxSizeMiB = 0
+	assert.Error(t, cfg.Validate())
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tmpstorageextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/extension/tmpstorageextension"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "tmp_storage"
+
+	defaultDirectory  = "/tmp/otelcol/storage"
+	defaultMaxSizeMiB = 64
+)
+
+// NewFactory returns a new factory for the tmp_storage extension.
+func NewFactory() component.ExtensionFactory {
+	return component.NewExtensionFactory(
+		typeStr,
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha)
+}
+
+func createDefaultConfig() component.ExtensionConfig {
+	return &Config{
+		ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+		Directory:         defaultDirectory,
+		MaxSizeMiB:        defaultMaxSizeMiB,
+	}
+}
+
+func createExtension(
+	_ context.Context,
+	set component.ExtensionCreateSettings,
+	cfg component.ExtensionConfig,
+) (component.Extension, error) {
+	return newTmpStorage(set.Logger, cfg.(*Config)), nil
+}
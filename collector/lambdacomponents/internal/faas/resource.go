@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faas // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+
+import (
+	"os"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	conventions "go.opentelemetry.io/collector/semconv/v1.12.0"
+)
+
+// processStart approximates the start of the init phase: the extension is started
+// as part of the environment's initialization.
+var processStart = time.Now()
+
+// ProcessStart returns the time at which the extension process started.
+func ProcessStart() time.Time {
+	return processStart
+}
+
+// ProactiveInitAttribute marks telemetry of an environment that Lambda initialized ahead
+// of demand, before any invocation was routed to it.
+const ProactiveInitAttribute = "aws.lambda.proactive_initialization"
+
+// DefaultProactiveInitThreshold is the default gap between the start of the extension and
+// the first invocation above which the initialization is deemed proactive. The init phase
+// is limited to 10 seconds, so an invocation arriving later did not wait for it.
+const DefaultProactiveInitThreshold = 10 * time.Second
+
+// IsProactiveInit reports whether the first invocation arrived more than threshold after
+// initStart. A non-positive threshold disables the detection.
+func IsProactiveInit(initStart, firstInvoke time.Time, threshold time.Duration) bool {
+	return threshold > 0 && firstInvoke.Sub(initStart) > threshold
+}
+
+// FillResource sets the attributes describing the function on res, from the
+// environment variables defined by the Lambda runtime.
+func FillResource(res pcommon.Resource) {
+	attrs := res.Attributes()
+	attrs.PutStr(conventions.AttributeCloudProvider, conventions.AttributeCloudProviderAWS)
+	attrs.PutStr(conventions.AttributeCloudPlatform, conventions.AttributeCloudPlatformAWSLambda)
+	for attr, env := range map[string]string{
+		conventions.AttributeCloudRegion: "AWS_REGION",
+		conventions.AttributeFaaSName:    "AWS_LAMBDA_FUNCTION_NAME",
+		conventions.AttributeFaaSVersion: "AWS_LAMBDA_FUNCTION_VERSION",
+	} {
+		if v, ok := os.LookupEnv(env); ok {
+			attrs.PutStr(attr, v)
+		}
+	}
+}
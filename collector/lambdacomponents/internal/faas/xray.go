@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package faas holds helpers shared by the Lambda-specific components.
+package faas // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// TraceContext is the trace context carried by an X-Ray tracing header.
+type TraceContext struct {
+	TraceID  pcommon.TraceID
+	ParentID pcommon.SpanID
+	Sampled  bool
+}
+
+// ParseXRayTraceHeader parses a header of the form
+// "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1".
+// The second return value is false when the header has no valid root trace ID.
+func ParseXRayTraceHeader(header string) (TraceContext, bool) {
+	var tc TraceContext
+	var hasRoot bool
+	for _, part := range strings.Split(header, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(part), "=")
+		if !found {
+			continue
+		}
+		switch key {
+		case "Root":
+			// 1-<8 hex digits epoch>-<24 hex digits identifier>
+			fields := strings.Split(value, "-")
+			if len(fields) != 3 || fields[0] != "1" {
+				return TraceContext{}, false
+			}
+			b, err := hex.DecodeString(fields[1] + fields[2])
+			if err != nil || len(b) != len(tc.TraceID) {
+				return TraceContext{}, false
+			}
+			copy(tc.TraceID[:], b)
+			hasRoot = true
+		case "Parent":
+			b, err := hex.DecodeString(value)
+			if err == nil && len(b) == len(tc.ParentID) {
+				copy(tc.ParentID[:], b)
+			}
+		case "Sampled":
+			tc.Sampled = value == "1"
+		}
+	}
+	return tc, hasRoot
+}
+
+// NewTraceID returns a random trace ID.
+func NewTraceID() pcommon.TraceID {
+	var id pcommon.TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+// NewSpanID returns a random span ID.
+func NewSpanID() pcommon.SpanID {
+	var id pcommon.SpanID
+	_, _ = rand.Read(id[:])
+	return id
+}
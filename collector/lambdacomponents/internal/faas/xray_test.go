@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package faas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestParseXRayTraceHeader(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		header   string
+		expected TraceContext
+		ok       bool
+	}{
+		{
+			name:   "full header",
+			header: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+			expected: TraceContext{
+				TraceID:  pcommon.TraceID([16]byte{0x57, 0x59, 0xe9, 0x88, 0xbd, 0x86, 0x2e, 0x3f, 0xe1, 0xbe, 0x46, 0xa9, 0x94, 0x27, 0x27, 0x93}),
+				ParentID: pcommon.SpanID([8]byte{0x53, 0x99, 0x5c, 0x3f, 0x42, 0xcd, 0x8a, 0xd8}),
+				Sampled:  true,
+			},
+			ok: true,
+		},
+		{
+			name:   "root only",
+			header: "Root=1-5759e988-bd862e3fe1be46a994272793",
+			expected: TraceContext{
+				TraceID: pcommon.TraceID([16]byte{0x57, 0x59, 0xe9, 0x88, 0xbd, 0x86, 0x2e, 0x3f, 0xe1, 0xbe, 0x46, 0xa9, 0x94, 0x27, 0x27, 0x93}),
+			},
+			ok: true,
+		},
+		{
+			name:   "empty",
+			header: "",
+		},
+		{
+			name:   "malformed root",
+			header: "Root=2-5759e988-bd862e3f;Parent=53995c3f42cd8ad8",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := ParseXRayTraceHeader(tc.header)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationbatchprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/invocationbatchprocessor"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the invocationbatch processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// MaxAge is how long telemetry may be held across invocations. The age is checked when an
+	// invocation ends, so telemetry may be held up to one invocation interval longer.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// MaxSize is the number of spans, data points or log records above which the batch is sent
+	// right away.
+	MaxSize int `mapstructure:"max_size"`
+
+	// Adaptive sends the batch when an invocation ends if, at the pace invocations have been arriving,
+	// it would be older than MaxAge when the next one ends, rather than sending it one invocation late.
+	Adaptive bool `mapstructure:"adaptive"`
+
+	// Passthrough sends telemetry on as it is received, so that it is exported during the invocation that
+	// produced it and export errors reach the receiver.
+	Passthrough bool `mapstructure:"passthrough"`
+
+	// FlushOrder orders the processors sending their batch when an invocation ends or the environment shuts
+	// down: lower values are sent first.
+	FlushOrder int `mapstructure:"flush_order"`
+
+	// FlushBudget is the share of the time left until the deadline that sending the batch may use when an
+	// invocation ends or the environment shuts down, leaving the rest to the processors sent after it.
+	FlushBudget float64 `mapstructure:"flush_budget"`
+}
+
+var _ component.ProcessorConfig = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.MaxAge <= 0 {
+		return errors.New("max_age must be positive")
+	}
+	if cfg.MaxSize <= 0 {
+		return errors.New("max_size must be positive")
+	}
+	if cfg.FlushBudget <= 0 || cfg.FlushBudget > 1 {
+		return errors.New("flush_budget must be greater than 0 and at most 1")
+	}
+	return nil
+}
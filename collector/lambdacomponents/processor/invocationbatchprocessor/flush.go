@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationbatchprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/invocationbatchprocessor"
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+)
+
+// flushGroup is the lifecycle listener of all the invocationbatch processors of the collector. It sends
+// their batches in flush_order when an invocation ends or the environment shuts down, each within its
+// flush_budget of the time left, so that the first pipelines are the least likely to run out of time.
+type flushGroup struct {
+	mu       sync.Mutex
+	notifier lambdalifecycle.Notifier
+	// batchers are sorted by flush_order, then by the time they started.
+	batchers []*batcher
+}
+
+var _ lambdalifecycle.FlushListener = (*flushGroup)(nil)
+
+var group = &flushGroup{}
+
+func (g *flushGroup) add(b *batcher) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if len(g.batchers) == 0 {
+		g.notifier = lambdalifecycle.GetNotifier()
+		if g.notifier != nil {
+			g.notifier.AddListener(g)
+		}
+	}
+	g.batchers = append(g.batchers, b)
+	sort.SliceStable(g.batchers, func(i, j int) bool {
+		return g.batchers[i].cfg.FlushOrder < g.batchers[j].cfg.FlushOrder
+	})
+}
+
+func (g *flushGroup) remove(b *batcher) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, other := range g.batchers {
+		if other == b {
+			g.batchers = append(g.batchers[:i], g.batchers[i+1:]...)
+			if len(g.batchers) == 0 && g.notifier != nil {
+				g.notifier.RemoveListener(g)
+			}
+			return
+		}
+	}
+}
+
+func (g *flushGroup) snapshot() []*batcher {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return append([]*batcher(nil), g.batchers...)
+}
+
+func (g *flushGroup) OnInvoke(ctx context.Context, event lambdalifecycle.InvokeEvent) {
+	for _, b := range g.snapshot() {
+		b.OnInvoke(ctx, event)
+	}
+}
+
+func (g *flushGroup) OnRuntimeDone(ctx context.Context, requestID string) {
+	for _, b := range g.snapshot() {
+		bctx, cancel := withBudget(ctx, b.cfg.FlushBudget)
+		b.OnRuntimeDone(bctx, requestID)
+		cancel()
+	}
+}
+
+func (g *flushGroup) OnShutdown(ctx context.Context, reason string) {
+	for _, b := range g.snapshot() {
+		bctx, cancel := withBudget(ctx, b.cfg.FlushBudget)
+		b.OnShutdown(bctx, reason)
+		cancel()
+	}
+}
+
+// OnFlush sends the batches held by the processors when function code asks for it, and returns the first error.
+func (g *flushGroup) OnFlush(ctx context.Context) error {
+	var first error
+	for _, b := range g.snapshot() {
+		b.retryFailed(ctx)
+		if err := b.flush(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// withBudget returns a context whose deadline is the given share of the time left until the deadline of ctx.
+func withBudget(ctx context.Context, budget float64) (context.Context, context.CancelFunc) {
+	deadline, ok := ctx.Deadline()
+	if !ok || budget >= 1 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, time.Duration(float64(time.Until(deadline))*budget))
+}
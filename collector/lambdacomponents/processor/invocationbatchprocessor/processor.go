@@ -0,0 +1,271 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationbatchprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/invocationbatchprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// batcher holds telemetry across invocations and sends it when the batch is full, when an
+// invocation ends with the batch older than max_age, or when the environment shuts down.
+// Unlike a timer-based batch, it never needs to run while the environment is frozen.
+//
+// A batch that fails to be sent when an invocation ends is retried once when the next
+// invocation starts, as the network is more reliable once the environment has thawed.
+type batcher struct {
+	cfg    *Config
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	size   int
+	oldest time.Time
+	// lastInvoke is when the last invocation started, and interval the moving average of the time
+	// between invocations. They are only tracked when the batch is adaptive.
+	lastInvoke time.Time
+	interval   time.Duration
+	// take moves the buffered data out and returns the function sending it. It is called with mu held.
+	take func() func(context.Context) error
+	// failed sends the batch whose send failed when the last invocation ended.
+	failed func(context.Context) error
+	wg     sync.WaitGroup
+}
+
+var _ lambdalifecycle.Listener = (*batcher)(nil)
+
+func newBatcher(set component.ProcessorCreateSettings, cfg *Config) *batcher {
+	return &batcher{cfg: cfg, logger: set.Logger, now: time.Now}
+}
+
+func (b *batcher) Start(context.Context, component.Host) error {
+	if !b.cfg.Passthrough {
+		group.add(b)
+	}
+	return nil
+}
+
+func (b *batcher) Shutdown(ctx context.Context) error {
+	group.remove(b)
+	b.wg.Wait()
+	b.retryFailed(ctx)
+	return b.flush(ctx)
+}
+
+func (b *batcher) OnInvoke(context.Context, lambdalifecycle.InvokeEvent) {
+	if b.cfg.Adaptive {
+		b.mu.Lock()
+		b.invokedLocked(b.now())
+		b.mu.Unlock()
+	}
+	// Retrying must not hold up the lifecycle loop, nor be cut short when the invocation ends.
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.retryFailed(context.Background())
+	}()
+}
+
+func (b *batcher) OnRuntimeDone(ctx context.Context, _ string) {
+	b.mu.Lock()
+	// Adaptive batches are checked against their age when the next invocation is expected to end.
+	expired := b.size > 0 && b.now().Sub(b.oldest)+b.interval >= b.cfg.MaxAge
+	var send func(context.Context) error
+	if expired {
+		send = b.takeLocked()
+	}
+	b.mu.Unlock()
+	if send == nil {
+		return
+	}
+	if err := send(ctx); err != nil {
+		b.logger.Warn("failed to send batch, retrying on the next invocation", zap.Error(err))
+		b.mu.Lock()
+		if b.failed != nil {
+			b.logger.Error("dropping batch that already failed to be sent")
+		}
+		b.failed = send
+		b.mu.Unlock()
+	}
+}
+
+func (b *batcher) OnShutdown(ctx context.Context, _ string) {
+	b.wg.Wait()
+	b.retryFailed(ctx)
+	if err := b.flush(ctx); err != nil {
+		b.logger.Error("failed to send batch", zap.Error(err))
+	}
+}
+
+// retryFailed sends the batch that failed when the last invocation ended, if any. It is
+// dropped if it fails again.
+func (b *batcher) retryFailed(ctx context.Context) {
+	b.mu.Lock()
+	send := b.failed
+	b.failed = nil
+	b.mu.Unlock()
+	if send == nil {
+		return
+	}
+	if err := send(ctx); err != nil {
+		b.logger.Error("failed to send batch again, dropping it", zap.Error(err))
+	}
+}
+
+// invokedLocked folds the time since the last invocation into the average interval between
+// invocations, giving the latest interval a weight of one quarter. It is called with mu held.
+func (b *batcher) invokedLocked(now time.Time) {
+	if !b.lastInvoke.IsZero() {
+		d := now.Sub(b.lastInvoke)
+		if b.interval == 0 {
+			b.interval = d
+		} else {
+			b.interval += (d - b.interval) / 4
+		}
+	}
+	b.lastInvoke = now
+}
+
+// added accounts for n items appended to the buffer and reports whether it is full.
+// It is called with mu held.
+func (b *batcher) added(n int) bool {
+	if b.size == 0 {
+		b.oldest = b.now()
+	}
+	b.size += n
+	return b.size >= b.cfg.MaxSize
+}
+
+func (b *batcher) flush(ctx context.Context) error {
+	b.mu.Lock()
+	send := b.takeLocked()
+	b.mu.Unlock()
+	if send == nil {
+		return nil
+	}
+	return send(ctx)
+}
+
+// takeLocked empties the buffer, returning nil if there is nothing to send. It is called with mu held.
+func (b *batcher) takeLocked() func(context.Context) error {
+	if b.size == 0 {
+		return nil
+	}
+	b.size = 0
+	return b.take()
+}
+
+func (b *batcher) consumed(ctx context.Context, full bool) error {
+	if full {
+		return b.flush(ctx)
+	}
+	return nil
+}
+
+type tracesProcessor struct {
+	*batcher
+	consumer.Traces
+}
+
+func (b *batcher) traces(next consumer.Traces) (component.TracesProcessor, error) {
+	pending := ptrace.NewTraces()
+	b.take = func() func(context.Context) error {
+		td := pending
+		pending = ptrace.NewTraces()
+		return func(ctx context.Context) error { return next.ConsumeTraces(ctx, td) }
+	}
+	c, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if b.cfg.Passthrough {
+			return next.ConsumeTraces(ctx, td)
+		}
+		b.mu.Lock()
+		n := td.SpanCount()
+		td.ResourceSpans().MoveAndAppendTo(pending.ResourceSpans())
+		full := b.added(n)
+		b.mu.Unlock()
+		return b.consumed(ctx, full)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &tracesProcessor{batcher: b, Traces: c}, nil
+}
+
+type metricsProcessor struct {
+	*batcher
+	consumer.Metrics
+}
+
+func (b *batcher) metrics(next consumer.Metrics) (component.MetricsProcessor, error) {
+	pending := pmetric.NewMetrics()
+	b.take = func() func(context.Context) error {
+		md := pending
+		pending = pmetric.NewMetrics()
+		return func(ctx context.Context) error { return next.ConsumeMetrics(ctx, md) }
+	}
+	c, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if b.cfg.Passthrough {
+			return next.ConsumeMetrics(ctx, md)
+		}
+		b.mu.Lock()
+		n := md.DataPointCount()
+		md.ResourceMetrics().MoveAndAppendTo(pending.ResourceMetrics())
+		full := b.added(n)
+		b.mu.Unlock()
+		return b.consumed(ctx, full)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &metricsProcessor{batcher: b, Metrics: c}, nil
+}
+
+type logsProcessor struct {
+	*batcher
+	consumer.Logs
+}
+
+func (b *batcher) logs(next consumer.Logs) (component.LogsProcessor, error) {
+	pending := plog.NewLogs()
+	b.take = func() func(context.Context) error {
+		ld := pending
+		pending = plog.NewLogs()
+		return func(ctx context.Context) error { return next.ConsumeLogs(ctx, ld) }
+	}
+	c, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		if b.cfg.Passthrough {
+			return next.ConsumeLogs(ctx, ld)
+		}
+		b.mu.Lock()
+		n := ld.LogRecordCount()
+		ld.ResourceLogs().MoveAndAppendTo(pending.ResourceLogs())
+		full := b.added(n)
+		b.mu.Unlock()
+		return b.consumed(ctx, full)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &logsProcessor{batcher: b, Logs: c}, nil
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationbatchprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func spans(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for i := 0; i < n; i++ {
+		ss.Spans().AppendEmpty()
+	}
+	return td
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+	cfg.MaxSize = 0
+	assert.Error(t, cfg.Validate())
+	cfg = createDefaultConfig().(*Config)
+	cfg.MaxAge = 0
+	assert.Error(t, cfg.Validate())
+	cfg = createDefaultConfig().(*Config)
+	cfg.FlushBudget = 1.5
+	assert.Error(t, cfg.Validate())
+}
+
+func TestTracesHeldAcrossInvocations(t *testing.T) {
+	ctx := context.Background()
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxAge = time.Hour
+	cfg.MaxSize = 5
+
+	tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	p := tp.(*tracesProcessor)
+
+	require.NoError(t, p.ConsumeTraces(ctx, spans(2)))
+	p.OnRuntimeDone(ctx, "1")
+	require.NoError(t, p.ConsumeTraces(ctx, spans(2)))
+	p.OnRuntimeDone(ctx, "2")
+	assert.Zero(t, sink.SpanCount(), "held while below max_size and max_age")
+
+	require.NoError(t, p.ConsumeTraces(ctx, spans(1)))
+	require.Len(t, sink.AllTraces(), 1, "sent once max_size is reached")
+	assert.Equal(t, 5, sink.SpanCount())
+
+	require.NoError(t, p.ConsumeTraces(ctx, spans(1)))
+	require.NoError(t, p.Shutdown(ctx))
+	assert.Equal(t, 6, sink.SpanCount(), "flushed on shutdown")
+}
+
+func TestLogsSentAfterMaxAge(t *testing.T) {
+	ctx := context.Background()
+	sink := new(consumertest.LogsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxAge = time.Millisecond
+
+	lp, err := NewFactory().CreateLogsProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	p := lp.(*logsProcessor)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, p.ConsumeLogs(ctx, ld))
+	assert.Zero(t, sink.LogRecordCount())
+
+	time.Sleep(2 * time.Millisecond)
+	p.OnRuntimeDone(ctx, "1")
+	assert.Equal(t, 1, sink.LogRecordCount())
+
+	p.OnShutdown(ctx, "spindown")
+	assert.Len(t, sink.AllLogs(), 1, "nothing left to flush")
+}
+
+func TestAdaptiveBatchFollowsInvocationPace(t *testing.T) {
+	ctx := context.Background()
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxAge = 10 * time.Second
+	cfg.Adaptive = true
+
+	tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	p := tp.(*tracesProcessor)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	invoke := func(after time.Duration) {
+		now = now.Add(after)
+		p.OnInvoke(ctx, lambdalifecycle.InvokeEvent{})
+	}
+
+	invoke(0)
+	invoke(6 * time.Second)
+	require.NoError(t, p.ConsumeTraces(ctx, spans(1)))
+	p.OnRuntimeDone(ctx, "2")
+	assert.Zero(t, sink.SpanCount(), "held while the next invocation is expected to end before max_age")
+
+	invoke(6 * time.Second)
+	p.OnRuntimeDone(ctx, "3")
+	assert.Equal(t, 1, sink.SpanCount(), "sent rather than held past max_age by the next invocation")
+
+	// Sporadic invocations: the batch is sent at the end of every invocation.
+	invoke(time.Minute)
+	invoke(time.Minute)
+	require.NoError(t, p.ConsumeTraces(ctx, spans(1)))
+	p.OnRuntimeDone(ctx, "5")
+	assert.Equal(t, 2, sink.SpanCount())
+	require.NoError(t, p.Shutdown(ctx))
+}
+
+// failingTraces fails the first sends, then forwards to the sink.
+type failingTraces struct {
+	*consumertest.TracesSink
+	failures int
+}
+
+func (f *failingTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("network unreachable")
+	}
+	return f.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestFailedBatchRetriedOnNextInvocation(t *testing.T) {
+	ctx := context.Background()
+	next := &failingTraces{TracesSink: new(consumertest.TracesSink), failures: 1}
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxAge = time.Nanosecond
+
+	tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	p := tp.(*tracesProcessor)
+
+	require.NoError(t, p.ConsumeTraces(ctx, spans(3)))
+	p.OnRuntimeDone(ctx, "1")
+	assert.Zero(t, next.SpanCount())
+
+	p.OnInvoke(ctx, lambdalifecycle.InvokeEvent{RequestID: "2"})
+	p.wg.Wait()
+	assert.Equal(t, 3, next.SpanCount())
+}
+
+func TestFailedBatchDroppedAfterRetry(t *testing.T) {
+	ctx := context.Background()
+	next := &failingTraces{TracesSink: new(consumertest.TracesSink), failures: 2}
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxAge = time.Nanosecond
+
+	tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	p := tp.(*tracesProcessor)
+
+	require.NoError(t, p.ConsumeTraces(ctx, spans(3)))
+	p.OnRuntimeDone(ctx, "1")
+	p.OnShutdown(ctx, "spindown")
+	require.NoError(t, p.Shutdown(ctx))
+	assert.Zero(t, next.SpanCount())
+}
+
+func TestPassthrough(t *testing.T) {
+	ctx := context.Background()
+	sink := new(consumertest.TracesSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.Passthrough = true
+
+	tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(ctx, componenttest.NewNopHost()))
+
+	require.NoError(t, tp.ConsumeTraces(ctx, spans(2)))
+	assert.Equal(t, 2, sink.SpanCount(), "sent right away")
+
+	fp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, &failingTraces{TracesSink: new(consumertest.TracesSink), failures: 1})
+	require.NoError(t, err)
+	assert.Error(t, fp.ConsumeTraces(ctx, spans(1)), "export errors are returned")
+	require.NoError(t, tp.Shutdown(ctx))
+}
+
+type notifier struct {
+	listeners []lambdalifecycle.Listener
+}
+
+func (n *notifier) AddListener(l lambdalifecycle.Listener) { n.listeners = append(n.listeners, l) }
+
+func (n *notifier) RemoveListener(l lambdalifecycle.Listener) {
+	for i, other := range n.listeners {
+		if other == l {
+			n.listeners = append(n.listeners[:i], n.listeners[i+1:]...)
+			return
+		}
+	}
+}
+
+// deadlineTraces records the pipelines in the order they were sent, with the time left until the deadline.
+type deadlineTraces struct {
+	*consumertest.TracesSink
+	name string
+	sent *[]string
+	left time.Duration
+}
+
+func (d *deadlineTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	*d.sent = append(*d.sent, d.name)
+	if deadline, ok := ctx.Deadline(); ok {
+		d.left = time.Until(deadline)
+	}
+	return d.TracesSink.ConsumeTraces(ctx, td)
+}
+
+func TestFlushOrderAndBudget(t *testing.T) {
+	n := &notifier{}
+	lambdalifecycle.SetNotifier(n)
+	defer lambdalifecycle.SetNotifier(nil)
+
+	var sent []string
+	ctx := context.Background()
+	create := func(name string, order int, budget float64) (*tracesProcessor, *deadlineTraces) {
+		cfg := createDefaultConfig().(*Config)
+		cfg.MaxAge = time.Nanosecond
+		cfg.FlushOrder = order
+		cfg.FlushBudget = budget
+		sink := &deadlineTraces{TracesSink: new(consumertest.TracesSink), name: name, sent: &sent}
+		tp, err := NewFactory().CreateTracesProcessor(ctx, componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+		require.NoError(t, err)
+		require.NoError(t, tp.Start(ctx, componenttest.NewNopHost()))
+		return tp.(*tracesProcessor), sink
+	}
+	logs, logsSink := create("logs", 1, 1)
+	traces, tracesSink := create("traces", 0, 0.5)
+	require.Len(t, n.listeners, 1, "processors share one listener")
+
+	require.NoError(t, logs.ConsumeTraces(ctx, spans(1)))
+	require.NoError(t, traces.ConsumeTraces(ctx, spans(1)))
+	eventCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	n.listeners[0].OnRuntimeDone(eventCtx, "1")
+
+	assert.Equal(t, []string{"traces", "logs"}, sent)
+	assert.InDelta(t, 30*time.Second, tracesSink.left, float64(time.Second), "half of the time left")
+	assert.InDelta(t, time.Minute, logsSink.left, float64(time.Second), "all of the time left")
+
+	require.NoError(t, logs.ConsumeTraces(ctx, spans(1)))
+	require.NoError(t, n.listeners[0].(lambdalifecycle.FlushListener).OnFlush(ctx))
+	assert.Equal(t, 2, logsSink.SpanCount(), "sent when flushed")
+
+	require.NoError(t, logs.Shutdown(ctx))
+	require.NoError(t, traces.Shutdown(ctx))
+	assert.Empty(t, n.listeners)
+}
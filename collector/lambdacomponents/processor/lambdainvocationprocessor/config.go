@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdainvocationprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/lambdainvocationprocessor"
+
+import (
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the lambdainvocation processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// PreserveMetadataKeys lists client metadata keys set by the receiver that are carried over
+	// alongside the invocation metadata. client.Metadata cannot be enumerated, so any other key is dropped.
+	PreserveMetadataKeys []string `mapstructure:"preserve_metadata_keys"`
+}
@@ -0,0 +1,85 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdainvocationprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/lambdainvocationprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "lambdainvocation"
+)
+
+var processorCapabilities = consumer.Capabilities{MutatesData: false}
+
+// NewFactory returns a new factory for the lambdainvocation processor.
+func NewFactory() component.ProcessorFactory {
+	return component.NewProcessorFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesProcessor(createTracesProcessor, component.StabilityLevelAlpha),
+		component.WithMetricsProcessor(createMetricsProcessor, component.StabilityLevelAlpha),
+		component.WithLogsProcessor(createLogsProcessor, component.StabilityLevelAlpha))
+}
+
+func createDefaultConfig() component.ProcessorConfig {
+	return &Config{
+		ProcessorSettings: config.NewProcessorSettings(component.NewID(typeStr)),
+	}
+}
+
+func createTracesProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg component.ProcessorConfig,
+	nextConsumer consumer.Traces,
+) (component.TracesProcessor, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	p := newInvocationProcessor(cfg.(*Config))
+	return p.traces(nextConsumer)
+}
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg component.ProcessorConfig,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	p := newInvocationProcessor(cfg.(*Config))
+	return p.metrics(nextConsumer)
+}
+
+func createLogsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg component.ProcessorConfig,
+	nextConsumer consumer.Logs,
+) (component.LogsProcessor, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	p := newInvocationProcessor(cfg.(*Config))
+	return p.logs(nextConsumer)
+}
@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdainvocationprocessor // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/processor/lambdainvocationprocessor"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Client metadata keys set by the processor. They can be read with from_context, e.g.
+// "metadata.lambda.request_id" in the attributes processor.
+const (
+	RequestIDKey          = "lambda.request_id"
+	InvokedFunctionArnKey = "lambda.invoked_function_arn"
+	DeadlineKey           = "lambda.deadline"
+)
+
+// invocationProcessor attaches the current invocation to the client metadata of the
+// data flowing through the pipeline. The current invocation is the last one announced by
+// the lifecycle notifier.
+type invocationProcessor struct {
+	cfg *Config
+
+	mu       sync.RWMutex
+	metadata map[string][]string
+}
+
+var _ lambdalifecycle.Listener = (*invocationProcessor)(nil)
+
+func newInvocationProcessor(cfg *Config) *invocationProcessor {
+	return &invocationProcessor{cfg: cfg}
+}
+
+func (p *invocationProcessor) Start(context.Context, component.Host) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.AddListener(p)
+	}
+	return nil
+}
+
+func (p *invocationProcessor) Shutdown(context.Context) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.RemoveListener(p)
+	}
+	return nil
+}
+
+func (p *invocationProcessor) OnInvoke(_ context.Context, event lambdalifecycle.InvokeEvent) {
+	md := map[string][]string{
+		RequestIDKey:          {event.RequestID},
+		InvokedFunctionArnKey: {event.InvokedFunctionArn},
+		DeadlineKey:           {event.Deadline.UTC().Format(time.RFC3339Nano)},
+	}
+	p.mu.Lock()
+	p.metadata = md
+	p.mu.Unlock()
+}
+
+func (p *invocationProcessor) OnRuntimeDone(context.Context, string) {}
+
+func (p *invocationProcessor) OnShutdown(context.Context, string) {}
+
+// contextWithInvocation returns ctx with the invocation metadata merged into its client.Info.
+func (p *invocationProcessor) contextWithInvocation(ctx context.Context) context.Context {
+	p.mu.RLock()
+	current := p.metadata
+	p.mu.RUnlock()
+	if current == nil {
+		return ctx
+	}
+
+	info := client.FromContext(ctx)
+	md := make(map[string][]string, len(current)+len(p.cfg.PreserveMetadataKeys))
+	for _, key := range p.cfg.PreserveMetadataKeys {
+		if vals := info.Metadata.Get(key); len(vals) > 0 {
+			md[key] = vals
+		}
+	}
+	for key, vals := range current {
+		md[key] = vals
+	}
+	info.Metadata = client.NewMetadata(md)
+	return client.NewContext(ctx, info)
+}
+
+type tracesProcessor struct {
+	*invocationProcessor
+	consumer.Traces
+}
+
+func (p *invocationProcessor) traces(next consumer.Traces) (component.TracesProcessor, error) {
+	c, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		return next.ConsumeTraces(p.contextWithInvocation(ctx), td)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &tracesProcessor{invocationProcessor: p, Traces: c}, nil
+}
+
+type metricsProcessor struct {
+	*invocationProcessor
+	consumer.Metrics
+}
+
+func (p *invocationProcessor) metrics(next consumer.Metrics) (component.MetricsProcessor, error) {
+	c, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		return next.ConsumeMetrics(p.contextWithInvocation(ctx), md)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &metricsProcessor{invocationProcessor: p, Metrics: c}, nil
+}
+
+type logsProcessor struct {
+	*invocationProcessor
+	consumer.Logs
+}
+
+func (p *invocationProcessor) logs(next consumer.Logs) (component.LogsProcessor, error) {
+	c, err := consumer.NewLogs(func(ctx context.Context, ld plog.Logs) error {
+		return next.ConsumeLogs(p.contextWithInvocation(ctx), ld)
+	}, consumer.WithCapabilities(processorCapabilities))
+	if err != nil {
+		return nil, err
+	}
+	return &logsProcessor{invocationProcessor: p, Logs: c}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdainvocationprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type notifier struct {
+	listeners []lambdalifecycle.Listener
+}
+
+func (n *notifier) AddListener(l lambdalifecycle.Listener) {
+	n.listeners = append(n.listeners, l)
+}
+
+func (n *notifier) RemoveListener(lambdalifecycle.Listener) {
+	n.listeners = nil
+}
+
+func TestInvocationMetadata(t *testing.T) {
+	n := &notifier{}
+	lambdalifecycle.SetNotifier(n)
+	defer lambdalifecycle.SetNotifier(nil)
+
+	var got client.Info
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		got = client.FromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.PreserveMetadataKeys = []string{"x-tenant"}
+	p, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	require.Len(t, n.listeners, 1)
+
+	incoming := client.NewContext(context.Background(), client.Info{
+		Metadata: client.NewMetadata(map[string][]string{"x-tenant": {"acme"}, "x-other": {"dropped"}}),
+	})
+
+	require.NoError(t, p.ConsumeTraces(incoming, ptrace.NewTraces()))
+	assert.Nil(t, got.Metadata.Get(RequestIDKey), "no invocation yet")
+	assert.Equal(t, []string{"dropped"}, got.Metadata.Get("x-other"), "metadata is untouched before the first invocation")
+
+	deadline := time.Date(2022, 10, 12, 0, 0, 3, 0, time.UTC)
+	n.listeners[0].OnInvoke(context.Background(), lambdalifecycle.InvokeEvent{
+		RequestID:          "8476a536-e9f4-11e8-9739-2dfe598c3fcd",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:custom-runtime",
+		Deadline:           deadline,
+	})
+
+	require.NoError(t, p.ConsumeTraces(incoming, ptrace.NewTraces()))
+	assert.Equal(t, []string{"8476a536-e9f4-11e8-9739-2dfe598c3fcd"}, got.Metadata.Get(RequestIDKey))
+	assert.Equal(t, []string{"arn:aws:lambda:us-east-1:123456789012:function:custom-runtime"}, got.Metadata.Get(InvokedFunctionArnKey))
+	assert.Equal(t, []string{"2022-10-12T00:00:03Z"}, got.Metadata.Get(DeadlineKey))
+	assert.Equal(t, []string{"acme"}, got.Metadata.Get("x-tenant"))
+	assert.Nil(t, got.Metadata.Get("x-other"))
+
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Empty(t, n.listeners)
+}
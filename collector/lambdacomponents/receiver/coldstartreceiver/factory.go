@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coldstartreceiver // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/coldstartreceiver"
+
+import (
+	"context"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "coldstart"
+)
+
+// NewFactory returns a new factory for the coldstart receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(createTracesReceiver, component.StabilityLevelAlpha),
+		component.WithMetricsReceiver(createMetricsReceiver, component.StabilityLevelAlpha))
+}
+
+func createDefaultConfig() component.ReceiverConfig {
+	return &Config{
+		ReceiverSettings:       config.NewReceiverSettings(component.NewID(typeStr)),
+		ProactiveInitThreshold: faas.DefaultProactiveInitThreshold,
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg component.ReceiverConfig,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r := newColdstartReceiver(set, cfg.(*Config))
+	r.traces = nextConsumer
+	return r, nil
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg component.ReceiverConfig,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	r := newColdstartReceiver(set, cfg.(*Config))
+	r.metrics = nextConsumer
+	return r, nil
+}
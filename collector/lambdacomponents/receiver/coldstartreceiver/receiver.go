@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coldstartreceiver // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/coldstartreceiver"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.12.0"
+	"go.uber.org/zap"
+)
+
+const (
+	scopeName       = "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/coldstartreceiver"
+	spanName        = "coldstart"
+	coldstartMetric = "faas.coldstarts"
+)
+
+// coldstartReceiver emits a span covering the initialization of the environment and a
+// counter data point when the first invocation of the environment ends.
+type coldstartReceiver struct {
+	logger  *zap.Logger
+	traces  consumer.Traces
+	metrics consumer.Metrics
+
+	initStart time.Time
+	threshold time.Duration
+
+	mu sync.Mutex
+	// first is the first invocation of the environment and invoked when it arrived, once it did.
+	first   *lambdalifecycle.InvokeEvent
+	invoked time.Time
+	// initEnd is when the runtime finished initializing, if the Telemetry API reported it.
+	initEnd time.Time
+	emitted bool
+}
+
+var _ lambdalifecycle.InitListener = (*coldstartReceiver)(nil)
+
+func newColdstartReceiver(set component.ReceiverCreateSettings, cfg *Config) *coldstartReceiver {
+	return &coldstartReceiver{
+		logger:    set.Logger,
+		initStart: faas.ProcessStart(),
+		threshold: cfg.ProactiveInitThreshold,
+	}
+}
+
+func (r *coldstartReceiver) Start(context.Context, component.Host) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.AddListener(r)
+	}
+	return nil
+}
+
+func (r *coldstartReceiver) Shutdown(context.Context) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.RemoveListener(r)
+	}
+	return nil
+}
+
+func (r *coldstartReceiver) OnInvoke(_ context.Context, event lambdalifecycle.InvokeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.first == nil {
+		r.first = &event
+		r.invoked = time.Now()
+	}
+}
+
+func (r *coldstartReceiver) OnInitDone(_ context.Context, end time.Time) {
+	r.mu.Lock()
+	r.initEnd = end
+	r.mu.Unlock()
+}
+
+// OnRuntimeDone emits the telemetry of the cold start once the first invocation ends, by when the Telemetry API
+// has usually reported the end of the init phase.
+func (r *coldstartReceiver) OnRuntimeDone(ctx context.Context, _ string) {
+	r.emit(ctx)
+}
+
+// OnShutdown emits the telemetry of the cold start if the first invocation never ended, e.g. because it timed out.
+func (r *coldstartReceiver) OnShutdown(ctx context.Context, _ string) {
+	r.emit(ctx)
+}
+
+func (r *coldstartReceiver) emit(ctx context.Context) {
+	r.mu.Lock()
+	if r.first == nil || r.emitted {
+		r.mu.Unlock()
+		return
+	}
+	r.emitted = true
+	event, invoked := *r.first, r.invoked
+	// The init phase ended by the time the first invocation arrived, which is used when it was not reported.
+	initEnd := r.initEnd
+	if initEnd.IsZero() || initEnd.After(invoked) {
+		initEnd = invoked
+	}
+	r.mu.Unlock()
+
+	if r.traces != nil {
+		if err := r.traces.ConsumeTraces(ctx, r.buildTraces(event, initEnd, invoked)); err != nil {
+			r.logger.Error("failed to emit cold start span", zap.Error(err))
+		}
+	}
+	if r.metrics != nil {
+		if err := r.metrics.ConsumeMetrics(ctx, r.buildMetrics(initEnd, invoked)); err != nil {
+			r.logger.Error("failed to emit cold start metric", zap.Error(err))
+		}
+	}
+}
+
+func (r *coldstartReceiver) buildTraces(event lambdalifecycle.InvokeEvent, initEnd, invoked time.Time) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	faas.FillResource(rs.Resource())
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+
+	span := ss.Spans().AppendEmpty()
+	span.SetName(spanName)
+	span.SetKind(ptrace.SpanKindInternal)
+	if tc, ok := faas.ParseXRayTraceHeader(event.TraceHeader); ok {
+		span.SetTraceID(tc.TraceID)
+		span.SetParentSpanID(tc.ParentID)
+	} else {
+		span.SetTraceID(faas.NewTraceID())
+	}
+	span.SetSpanID(faas.NewSpanID())
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(r.initStart))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(initEnd))
+	span.Attributes().PutBool(conventions.AttributeFaaSColdstart, true)
+	span.Attributes().PutStr(conventions.AttributeFaaSExecution, event.RequestID)
+	span.Attributes().PutBool(faas.ProactiveInitAttribute, faas.IsProactiveInit(r.initStart, invoked, r.threshold))
+	return td
+}
+
+func (r *coldstartReceiver) buildMetrics(initEnd, invoked time.Time) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	faas.FillResource(rm.Resource())
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(coldstartMetric)
+	m.SetDescription("Number of invocations served by a newly initialized environment")
+	m.SetUnit("1")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	sum.SetIsMonotonic(true)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pcommon.NewTimestampFromTime(r.initStart))
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(initEnd))
+	dp.SetIntValue(1)
+	dp.Attributes().PutBool(faas.ProactiveInitAttribute, faas.IsProactiveInit(r.initStart, invoked, r.threshold))
+	return md
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package coldstartreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+)
+
+type notifier struct {
+	listeners []lambdalifecycle.Listener
+}
+
+func (n *notifier) AddListener(l lambdalifecycle.Listener) {
+	n.listeners = append(n.listeners, l)
+}
+
+func (n *notifier) RemoveListener(lambdalifecycle.Listener) {
+	n.listeners = nil
+}
+
+func (n *notifier) invoke(requestID string) {
+	for _, l := range n.listeners {
+		l.OnInvoke(context.Background(), lambdalifecycle.InvokeEvent{
+			RequestID:   requestID,
+			Deadline:    time.Now().Add(3 * time.Second),
+			TraceHeader: "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+		})
+	}
+}
+
+func (n *notifier) initDone(end time.Time) {
+	for _, l := range n.listeners {
+		l.(lambdalifecycle.InitListener).OnInitDone(context.Background(), end)
+	}
+}
+
+func (n *notifier) runtimeDone(requestID string) {
+	for _, l := range n.listeners {
+		l.OnRuntimeDone(context.Background(), requestID)
+	}
+}
+
+func TestColdstartReceiver(t *testing.T) {
+	n := &notifier{}
+	lambdalifecycle.SetNotifier(n)
+	defer lambdalifecycle.SetNotifier(nil)
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "my-function")
+
+	ctx := context.Background()
+	set := componenttest.NewNopReceiverCreateSettings()
+	cfg := createDefaultConfig()
+	traces, metrics := new(consumertest.TracesSink), new(consumertest.MetricsSink)
+
+	tr, err := NewFactory().CreateTracesReceiver(ctx, set, cfg, traces)
+	require.NoError(t, err)
+	mr, err := NewFactory().CreateMetricsReceiver(ctx, set, cfg, metrics)
+	require.NoError(t, err)
+	require.NoError(t, tr.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, mr.Start(ctx, componenttest.NewNopHost()))
+
+	initEnd := time.Now()
+	n.invoke("first")
+	n.initDone(initEnd)
+	n.runtimeDone("first")
+	n.invoke("second")
+	n.runtimeDone("second")
+	require.NoError(t, tr.Shutdown(ctx))
+	require.NoError(t, mr.Shutdown(ctx))
+
+	require.Len(t, traces.AllTraces(), 1)
+	rs := traces.AllTraces()[0].ResourceSpans().At(0)
+	name, _ := rs.Resource().Attributes().Get("faas.name")
+	assert.Equal(t, "my-function", name.Str())
+	span := rs.ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, "coldstart", span.Name())
+	assert.Equal(t, "5759e988bd862e3fe1be46a994272793", span.TraceID().String())
+	assert.Equal(t, "53995c3f42cd8ad8", span.ParentSpanID().String())
+	coldstart, _ := span.Attributes().Get("faas.coldstart")
+	assert.True(t, coldstart.Bool())
+	execution, _ := span.Attributes().Get("faas.execution")
+	assert.Equal(t, "first", execution.Str())
+	proactive, _ := span.Attributes().Get("aws.lambda.proactive_initialization")
+	assert.False(t, proactive.Bool())
+	assert.LessOrEqual(t, span.StartTimestamp(), span.EndTimestamp())
+	assert.Equal(t, initEnd.UnixNano(), span.EndTimestamp().AsTime().UnixNano(), "span ends with the init phase")
+
+	require.Len(t, metrics.AllMetrics(), 1)
+	m := metrics.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "faas.coldstarts", m.Name())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.EqualValues(t, 1, m.Sum().DataPoints().At(0).IntValue())
+}
+
+func TestColdstartReceiverProactiveInit(t *testing.T) {
+	ctx := context.Background()
+	metrics := new(consumertest.MetricsSink)
+	mr, err := NewFactory().CreateMetricsReceiver(ctx, componenttest.NewNopReceiverCreateSettings(), createDefaultConfig(), metrics)
+	require.NoError(t, err)
+	r := mr.(*coldstartReceiver)
+	r.initStart = time.Now().Add(-time.Minute)
+
+	r.OnInvoke(ctx, lambdalifecycle.InvokeEvent{RequestID: "first"})
+	r.OnShutdown(ctx, "timeout")
+
+	require.Len(t, metrics.AllMetrics(), 1)
+	dp := metrics.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0)
+	proactive, _ := dp.Attributes().Get("aws.lambda.proactive_initialization")
+	assert.True(t, proactive.Bool())
+}
+
+func TestColdstartReceiverInitEndClampedToInvocation(t *testing.T) {
+	ctx := context.Background()
+	traces := new(consumertest.TracesSink)
+	tr, err := NewFactory().CreateTracesReceiver(ctx, componenttest.NewNopReceiverCreateSettings(), createDefaultConfig(), traces)
+	require.NoError(t, err)
+	r := tr.(*coldstartReceiver)
+
+	r.OnInvoke(ctx, lambdalifecycle.InvokeEvent{RequestID: "first"})
+	invoked := r.invoked
+	r.OnInitDone(ctx, invoked.Add(time.Second))
+	r.OnRuntimeDone(ctx, "first")
+	r.OnShutdown(ctx, "spindown")
+
+	require.Len(t, traces.AllTraces(), 1, "emitted once")
+	span := traces.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, invoked.UnixNano(), span.EndTimestamp().AsTime().UnixNano())
+}
@@ -0,0 +1,31 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationreceiver // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/invocationreceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the invocation receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// ProactiveInitThreshold is the delay between the start of the environment and its first
+	// invocation above which the environment is considered proactively initialized, and the
+	// invocation not a cold start. Zero disables the detection.
+	ProactiveInitThreshold time.Duration `mapstructure:"proactive_init_threshold"`
+}
@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationreceiver // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/invocationreceiver"
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.12.0"
+	"go.uber.org/zap"
+)
+
+const scopeName = "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/receiver/invocationreceiver"
+
+type invocation struct {
+	event     lambdalifecycle.InvokeEvent
+	start     time.Time
+	coldstart bool
+}
+
+// invocationReceiver emits one server span per invocation, from the moment the extension
+// is told about the invocation until the runtime has returned its response.
+type invocationReceiver struct {
+	logger   *zap.Logger
+	next     consumer.Traces
+	spanName string
+
+	initStart time.Time
+	threshold time.Duration
+
+	mu        sync.Mutex
+	pending   map[string]invocation
+	coldstart bool
+}
+
+var _ lambdalifecycle.Listener = (*invocationReceiver)(nil)
+
+func newInvocationReceiver(set component.ReceiverCreateSettings, cfg *Config, next consumer.Traces) *invocationReceiver {
+	spanName, ok := os.LookupEnv("AWS_LAMBDA_FUNCTION_NAME")
+	if !ok {
+		spanName = "invocation"
+	}
+	return &invocationReceiver{
+		logger:    set.Logger,
+		next:      next,
+		spanName:  spanName,
+		initStart: faas.ProcessStart(),
+		threshold: cfg.ProactiveInitThreshold,
+		pending:   make(map[string]invocation),
+		coldstart: true,
+	}
+}
+
+func (r *invocationReceiver) Start(context.Context, component.Host) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.AddListener(r)
+	}
+	return nil
+}
+
+func (r *invocationReceiver) Shutdown(context.Context) error {
+	if n := lambdalifecycle.GetNotifier(); n != nil {
+		n.RemoveListener(r)
+	}
+	return nil
+}
+
+func (r *invocationReceiver) OnInvoke(_ context.Context, event lambdalifecycle.InvokeEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	start := time.Now()
+	// A proactively initialized environment is already warm when its first invocation arrives.
+	coldstart := r.coldstart && !faas.IsProactiveInit(r.initStart, start, r.threshold)
+	r.pending[event.RequestID] = invocation{event: event, start: start, coldstart: coldstart}
+	r.coldstart = false
+}
+
+// OnRuntimeDone emits the span of the invocation. The notifier calls receivers before the processors started
+// ahead of them, so the span reaches a batch flushed when the invocation ends.
+func (r *invocationReceiver) OnRuntimeDone(ctx context.Context, requestID string) {
+	end := time.Now()
+	r.mu.Lock()
+	inv, ok := r.pending[requestID]
+	delete(r.pending, requestID)
+	r.mu.Unlock()
+	if ok {
+		r.emit(ctx, []invocation{inv}, end, ptrace.StatusCodeUnset)
+	}
+}
+
+// OnShutdown ends the spans of invocations that never reported runtimeDone, e.g. because
+// the function timed out or the runtime crashed.
+func (r *invocationReceiver) OnShutdown(ctx context.Context, _ string) {
+	end := time.Now()
+	r.mu.Lock()
+	invocations := make([]invocation, 0, len(r.pending))
+	for id, inv := range r.pending {
+		invocations = append(invocations, inv)
+		delete(r.pending, id)
+	}
+	r.mu.Unlock()
+	if len(invocations) > 0 {
+		r.emit(ctx, invocations, end, ptrace.StatusCodeError)
+	}
+}
+
+func (r *invocationReceiver) emit(ctx context.Context, invocations []invocation, end time.Time, status ptrace.StatusCode) {
+	if err := r.next.ConsumeTraces(ctx, r.buildTraces(invocations, end, status)); err != nil {
+		r.logger.Error("failed to emit invocation span", zap.Error(err))
+	}
+}
+
+func (r *invocationReceiver) buildTraces(invocations []invocation, end time.Time, status ptrace.StatusCode) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	faas.FillResource(rs.Resource())
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName(scopeName)
+
+	for _, inv := range invocations {
+		span := ss.Spans().AppendEmpty()
+		span.SetName(r.spanName)
+		span.SetKind(ptrace.SpanKindServer)
+		if tc, ok := faas.ParseXRayTraceHeader(inv.event.TraceHeader); ok {
+			span.SetTraceID(tc.TraceID)
+			span.SetParentSpanID(tc.ParentID)
+		} else {
+			span.SetTraceID(faas.NewTraceID())
+		}
+		span.SetSpanID(faas.NewSpanID())
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(inv.start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+		span.Status().SetCode(status)
+
+		attrs := span.Attributes()
+		attrs.PutStr(conventions.AttributeFaaSExecution, inv.event.RequestID)
+		attrs.PutStr(conventions.AttributeFaaSID, inv.event.InvokedFunctionArn)
+		attrs.PutBool(conventions.AttributeFaaSColdstart, inv.coldstart)
+	}
+	return td
+}
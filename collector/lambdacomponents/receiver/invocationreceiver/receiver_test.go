@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package invocationreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/internal/faas"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+func TestInvocationReceiver(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_FUNCTION_NAME", "my-function")
+	ctx := context.Background()
+	sink := new(consumertest.TracesSink)
+
+	rcv, err := NewFactory().CreateTracesReceiver(ctx, componenttest.NewNopReceiverCreateSettings(), createDefaultConfig(), sink)
+	require.NoError(t, err)
+	r := rcv.(*invocationReceiver)
+
+	r.OnInvoke(ctx, lambdalifecycle.InvokeEvent{
+		RequestID:          "first",
+		InvokedFunctionArn: "arn:aws:lambda:us-east-1:123456789012:function:my-function",
+		TraceHeader:        "Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+	})
+	r.OnRuntimeDone(ctx, "first")
+	require.Len(t, sink.AllTraces(), 1, "span emitted before OnRuntimeDone returns")
+	r.OnInvoke(ctx, lambdalifecycle.InvokeEvent{RequestID: "second"})
+	r.OnRuntimeDone(ctx, "unknown")
+	r.OnShutdown(ctx, "timeout")
+	require.NoError(t, r.Shutdown(ctx))
+
+	spans := map[string]ptrace.Span{}
+	for _, td := range sink.AllTraces() {
+		ss := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < ss.Len(); i++ {
+			id, _ := ss.At(i).Attributes().Get("faas.execution")
+			spans[id.Str()] = ss.At(i)
+		}
+	}
+	require.Len(t, spans, 2)
+
+	first := spans["first"]
+	assert.Equal(t, "my-function", first.Name())
+	assert.Equal(t, ptrace.SpanKindServer, first.Kind())
+	assert.Equal(t, "5759e988bd862e3fe1be46a994272793", first.TraceID().String())
+	assert.Equal(t, "53995c3f42cd8ad8", first.ParentSpanID().String())
+	arn, _ := first.Attributes().Get("faas.id")
+	assert.Equal(t, "arn:aws:lambda:us-east-1:123456789012:function:my-function", arn.Str())
+	coldstart, _ := first.Attributes().Get("faas.coldstart")
+	assert.True(t, coldstart.Bool())
+	assert.Equal(t, ptrace.StatusCodeUnset, first.Status().Code())
+
+	second := spans["second"]
+	assert.False(t, second.TraceID().IsEmpty())
+	assert.True(t, second.ParentSpanID().IsEmpty())
+	coldstart, _ = second.Attributes().Get("faas.coldstart")
+	assert.False(t, coldstart.Bool())
+	assert.Equal(t, ptrace.StatusCodeError, second.Status().Code(), "span ended by shutdown")
+}
+
+func TestInvocationReceiverProactiveInit(t *testing.T) {
+	ctx := context.Background()
+	for threshold, coldstart := range map[time.Duration]bool{
+		faas.DefaultProactiveInitThreshold: false,
+		2 * time.Minute:                    true,
+		0:                                  true,
+	} {
+		sink := new(consumertest.TracesSink)
+		cfg := createDefaultConfig().(*Config)
+		cfg.ProactiveInitThreshold = threshold
+		rcv, err := NewFactory().CreateTracesReceiver(ctx, componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+		require.NoError(t, err)
+		r := rcv.(*invocationReceiver)
+		r.initStart = time.Now().Add(-time.Minute)
+
+		r.OnInvoke(ctx, lambdalifecycle.InvokeEvent{RequestID: "first"})
+		r.OnRuntimeDone(ctx, "first")
+		require.NoError(t, r.Shutdown(ctx))
+
+		require.Len(t, sink.AllTraces(), 1)
+		span := sink.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+		actual, _ := span.Attributes().Get("faas.coldstart")
+		assert.Equal(t, coldstart, actual.Bool(), threshold)
+	}
+}
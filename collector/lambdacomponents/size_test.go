@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build lambdacomponents.size
+
+package lambdacomponents
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+var (
+	componentFile = regexp.MustCompile(`^(receiver|exporter|processor|extension)_([a-z0-9]+)\.go$`)
+	initClock     = regexp.MustCompile(`(?m)^init \S+ @\S+ ms, ([0-9.]+) ms clock`)
+
+	// binaries maps build tags to the binary built with them, since a benchmark function can run more than once.
+	binaries = make(map[string]string)
+)
+
+// BenchmarkComponentSize reports how much each component adds to the size of the layer's binary and to the
+// time spent initializing it, compared to a build without any component. Each component is built alone with
+// lambdacomponents.custom, so that dependencies shared by several components are attributed to all of them.
+//
+//	go test -tags lambdacomponents.size -run NONE -bench ComponentSize -benchtime 10x .
+func BenchmarkComponentSize(b *testing.B) {
+	dir := b.TempDir()
+	baseSize, baseInit := measure(b, dir, "lambdacomponents.custom", 10)
+
+	builds := map[string]string{"default": ""}
+	for _, tag := range componentTags(b) {
+		builds[tag] = "lambdacomponents.custom," + tag
+	}
+	names := make([]string, 0, len(builds))
+	for name := range builds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tags := builds[name]
+		b.Run(name, func(b *testing.B) {
+			size, init := measure(b, dir, tags, b.N)
+			b.ReportMetric(float64(size-baseSize), "bytes")
+			b.ReportMetric(init-baseInit, "init-ms")
+			b.ReportMetric(0, "ns/op")
+		})
+	}
+}
+
+func componentTags(b *testing.B) []string {
+	files, err := filepath.Glob("*_*.go")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var tags []string
+	for _, f := range files {
+		if m := componentFile.FindStringSubmatch(f); m != nil {
+			tags = append(tags, "lambdacomponents."+m[1]+"."+m[2])
+		}
+	}
+	return tags
+}
+
+// measure builds testdata/sizemain with tags like the layer is built, and returns the size of the binary and
+// the mean time spent in package initialization over runs executions, as traced by the runtime.
+func measure(b *testing.B, dir string, tags string, runs int) (int64, float64) {
+	b.StopTimer()
+	defer b.StartTimer()
+
+	bin, ok := binaries[tags]
+	if !ok {
+		bin = filepath.Join(dir, "sizemain"+strconv.Itoa(len(binaries)))
+		build := exec.Command("go", "build", "-trimpath", "-tags", tags, "-ldflags", "-s -w", "-o", bin, "./testdata/sizemain")
+		build.Env = append(os.Environ(), "CGO_ENABLED=0")
+		if out, err := build.CombinedOutput(); err != nil {
+			b.Fatalf("failed to build with tags %q: %v\n%s", tags, err, out)
+		}
+		binaries[tags] = bin
+	}
+	info, err := os.Stat(bin)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var total float64
+	for i := 0; i < runs; i++ {
+		run := exec.Command(bin)
+		run.Env = append(os.Environ(), "GODEBUG=inittrace=1")
+		var stderr bytes.Buffer
+		run.Stderr = &stderr
+		if err = run.Run(); err != nil {
+			b.Fatalf("failed to run the build with tags %q: %v\n%s", tags, err, stderr.Bytes())
+		}
+		for _, m := range initClock.FindAllSubmatch(stderr.Bytes(), -1) {
+			ms, _ := strconv.ParseFloat(string(m[1]), 64)
+			total += ms
+		}
+	}
+	return info.Size(), total / float64(runs)
+}
@@ -0,0 +1,200 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package telemetryapitest provides a test double for the Lambda Telemetry API, so that
+// subscribers and listeners can be exercised without a Lambda sandbox.
+package telemetryapitest // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdacomponents/telemetryapitest"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	subscribePath            = "/2022-07-01/telemetry"
+	extensionIdentiferHeader = "Lambda-Extension-Identifier"
+)
+
+// Subscription is a subscribe request received by the Server.
+type Subscription struct {
+	ExtensionID   string
+	SchemaVersion string
+	Types         []string
+	URI           string
+	// Status is the HTTP status the server answered with.
+	Status int
+}
+
+// ResponseFunc decides the HTTP status returned for a subscribe request.
+type ResponseFunc func(Subscription) int
+
+// Server implements the Telemetry API subscribe endpoint and replays event batches
+// to the subscribed destination.
+type Server struct {
+	srv        *httptest.Server
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	respond       ResponseFunc
+	subscriptions []Subscription
+}
+
+// NewServer starts a Server that accepts every subscription.
+func NewServer() *Server {
+	s := &Server{
+		httpClient: &http.Client{},
+		respond:    func(Subscription) int { return http.StatusOK },
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.handleSubscribe))
+	return s
+}
+
+// RuntimeAPI returns the host:port of the server, suitable for AWS_LAMBDA_RUNTIME_API.
+func (s *Server) RuntimeAPI() string {
+	return strings.TrimPrefix(s.srv.URL, "http://")
+}
+
+// SetResponse replaces the function deciding the status of subscribe requests.
+func (s *Server) SetResponse(f ResponseFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.respond = f
+}
+
+// Subscriptions returns every subscribe request received so far, including rejected ones.
+func (s *Server) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Subscription(nil), s.subscriptions...)
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+func (s *Server) handleSubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut || r.URL.Path != subscribePath {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var req struct {
+		SchemaVersion string   `json:"schemaVersion"`
+		Types         []string `json:"types"`
+		Destination   struct {
+			URI string `json:"URI"`
+		} `json:"destination"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	sub := Subscription{
+		ExtensionID:   r.Header.Get(extensionIdentiferHeader),
+		SchemaVersion: req.SchemaVersion,
+		Types:         req.Types,
+		URI:           req.Destination.URI,
+	}
+
+	s.mu.Lock()
+	sub.Status = s.respond(sub)
+	s.subscriptions = append(s.subscriptions, sub)
+	s.mu.Unlock()
+
+	w.WriteHeader(sub.Status)
+	if sub.Status == http.StatusOK {
+		_, _ = w.Write([]byte("OK"))
+	}
+}
+
+// Replay posts each batch to the destination of the latest accepted subscription, waiting
+// interval between batches. Each batch must be a JSON array of Telemetry API events.
+func (s *Server) Replay(ctx context.Context, batches [][]byte, interval time.Duration) error {
+	uri, err := s.destination()
+	if err != nil {
+		return err
+	}
+
+	for i, batch := range batches {
+		if i > 0 && interval > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(interval):
+			}
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(batch))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := s.httpClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to deliver batch %d: %w", i, err)
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return nil
+}
+
+func (s *Server) destination() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.subscriptions) - 1; i >= 0; i-- {
+		if s.subscriptions[i].Status == http.StatusOK {
+			return s.subscriptions[i].URI, nil
+		}
+	}
+	return "", errors.New("no accepted subscription to replay events to")
+}
+
+// LoadBatches reads a recorded event stream where each non-empty line is one batch,
+// i.e. a JSON array of events as posted by the Telemetry API.
+func LoadBatches(path string) ([][]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var batches [][]byte
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if !json.Valid(line) {
+			return nil, fmt.Errorf("invalid batch in %s: %s", path, line)
+		}
+		batches = append(batches, append([]byte(nil), line...))
+	}
+	return batches, scanner.Err()
+}
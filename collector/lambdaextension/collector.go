@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdaextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdaextension"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/audit"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/checksumconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/disablequeuedretryconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/exporterdefaultsconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/filerotationconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/keepaliveconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/proxyconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/tlsversionconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/converter/unixsocketconverter"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/confmap/provider/s3provider"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/provider/envprovider"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/service"
+	"go.uber.org/zap"
+)
+
+var (
+	// Version variable will be replaced at link time after `make` has been run.
+	Version = "latest"
+
+	// GitHash variable will be replaced at link time after `make` has been run.
+	GitHash = "<NOT PROPERLY GENERATED>"
+)
+
+// envConfigSchemes is the environment variable restricting the schemes of the config URIs, as a comma-separated
+// list, e.g. file,s3, so that platform teams can keep the configuration in the sources they control.
+const envConfigSchemes = "OPENTELEMETRY_EXTENSION_CONFIG_SCHEMES"
+
+// stateCheckInterval is how often the collector state is checked while waiting for it to start.
+const stateCheckInterval = 5 * time.Millisecond
+
+// Collector implements the OtelcolRunner interfaces running a single otelcol as a go routine within the
+// same process as the test executor.
+type Collector struct {
+	factories      component.Factories
+	loggingOptions []zap.Option
+	configProvider *timedConfigProvider
+	configURI      string
+	// configChecksum is set by the last converter, as the configuration is resolved.
+	configChecksum string
+	svc            *service.Collector
+	appDone        chan struct{}
+	// appErr is the error returned by the collector's Run. It is only read once appDone is closed.
+	appErr  error
+	stopped bool
+}
+
+func getConfig(logger *zap.Logger) string {
+	val, ex := os.LookupEnv("OPENTELEMETRY_COLLECTOR_CONFIG_FILE")
+	if !ex {
+		return "/opt/collector-config/config.yaml"
+	}
+	logger.Info("Using config URI from environment", zap.String("uri", val))
+	return val
+}
+
+// allowedSchemes returns the schemes of the URIs the configuration may be loaded from, both the one of the
+// configuration and the ones referenced in its values, e.g. "${s3:...}", or nil if they are not restricted.
+// Environment variables referenced as $VAR or ${VAR} are expanded by the expandconverter, not by the env
+// provider, so they are expanded even when env is not allowed.
+func allowedSchemes(logger *zap.Logger) map[string]bool {
+	val := os.Getenv(envConfigSchemes)
+	allowed := parseSchemes(val)
+	if allowed != nil {
+		logger.Info("Restricting the schemes of the config URIs", zap.String("schemes", val))
+	}
+	return allowed
+}
+
+// parseSchemes parses a comma-separated list of schemes, or returns nil if val is empty.
+func parseSchemes(val string) map[string]bool {
+	if val == "" {
+		return nil
+	}
+	allowed := make(map[string]bool)
+	for _, scheme := range strings.Split(val, ",") {
+		if scheme = strings.TrimSpace(scheme); scheme != "" {
+			allowed[scheme] = true
+		}
+	}
+	return allowed
+}
+
+// filterProviders returns the providers whose scheme is allowed, keyed by scheme, warning about the allowed
+// schemes no provider serves. All providers are returned if allowed is nil.
+func filterProviders(logger *zap.Logger, providers []confmap.Provider, allowed map[string]bool) map[string]confmap.Provider {
+	filtered := make(map[string]confmap.Provider, len(providers))
+	for _, provider := range providers {
+		if allowed == nil || allowed[provider.Scheme()] {
+			filtered[provider.Scheme()] = provider
+		}
+	}
+	for scheme := range allowed {
+		if _, ok := filtered[scheme]; !ok {
+			logger.Warn("Unknown config URI scheme allowed", zap.String("scheme", scheme))
+		}
+	}
+	return filtered
+}
+
+// NewCollector returns a Collector running the components built by factories. The loggingOptions are applied
+// to the logger of the collector.
+func NewCollector(logger *zap.Logger, factories component.Factories, loggingOptions ...zap.Option) *Collector {
+	l := logger.Named("NewCollector")
+	uri := getConfig(l)
+	checksum := os.Getenv(s3provider.EnvChecksum)
+	if checksum != "" && !strings.HasPrefix(uri, "s3:") {
+		l.Fatal("The checksum of the config can only be verified for s3 URIs", zap.String("uri", uri))
+	}
+	providers := []confmap.Provider{fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New(), s3provider.New(uri, checksum)}
+	mapProvider := filterProviders(l, providers, allowedSchemes(l))
+
+	socket := os.Getenv(unixsocketconverter.EnvSocket)
+	if socket != "" {
+		removeStaleSocket(l, socket)
+	}
+
+	col := &Collector{
+		factories:      factories,
+		loggingOptions: loggingOptions,
+		configURI:      uri,
+	}
+	recordChecksum := func(checksum string) { col.configChecksum = checksum }
+
+	cfgSet := service.ConfigProviderSettings{
+		ResolverSettings: confmap.ResolverSettings{
+			URIs:       []string{uri},
+			Providers:  mapProvider,
+			Converters: []confmap.Converter{expandconverter.New(), disablequeuedretryconverter.New(os.Getenv(disablequeuedretryconverter.EnvSyncExport) == "true"), exporterdefaultsconverter.New(), filerotationconverter.New(), keepaliveconverter.New(), proxyconverter.New(), tlsversionconverter.New(os.Getenv(tlsversionconverter.EnvMinVersion)), unixsocketconverter.New(socket), checksumconverter.New(recordChecksum)},
+		},
+	}
+	cfgProvider, err := service.NewConfigProvider(cfgSet)
+
+	if err != nil {
+		l.Fatal("error creating config provider", zap.Error(err))
+	}
+
+	col.configProvider = &timedConfigProvider{ConfigProvider: cfgProvider}
+	return col
+}
+
+// timedConfigProvider measures how long the first resolution of the configuration takes, and keeps its result.
+type timedConfigProvider struct {
+	service.ConfigProvider
+	resolved time.Duration
+	cfg      *service.Config
+}
+
+func (p *timedConfigProvider) Get(ctx context.Context, factories component.Factories) (*service.Config, error) {
+	start := time.Now()
+	cfg, err := p.ConfigProvider.Get(ctx, factories)
+	if p.resolved == 0 {
+		p.resolved = time.Since(start)
+		p.cfg = cfg
+	}
+	return cfg, err
+}
+
+// removeStaleSocket removes the socket left by a previous run of the extension in the execution environment,
+// which the otlp receiver could not listen on otherwise.
+func removeStaleSocket(logger *zap.Logger, path string) {
+	info, err := os.Stat(path)
+	if err != nil || info.Mode()&os.ModeSocket == 0 {
+		return
+	}
+	if err = os.Remove(path); err != nil {
+		logger.Warn("Cannot remove stale OTLP socket", zap.String("path", path), zap.Error(err))
+	}
+}
+
+func (c *Collector) Start(ctx context.Context) error {
+	params := service.CollectorSettings{
+		BuildInfo: component.BuildInfo{
+			Command:     "otelcol-lambda",
+			Description: "Lambda Collector",
+			Version:     Version,
+		},
+		ConfigProvider: c.configProvider,
+		Factories:      c.factories,
+		LoggingOptions: c.loggingOptions,
+	}
+	var err error
+	c.svc, err = service.New(params)
+	if err != nil {
+		return err
+	}
+
+	c.appDone = make(chan struct{})
+
+	go func() {
+		defer close(c.appDone)
+		c.appErr = c.svc.Run(ctx)
+	}()
+
+	ticker := time.NewTicker(stateCheckInterval)
+	defer ticker.Stop()
+	for {
+		switch state := c.svc.GetState(); state {
+		case service.StateStarting:
+			// NoOp
+		case service.StateRunning:
+			return nil
+		default:
+			// Closing: Run has failed or is about to return.
+			<-c.appDone
+			return c.startError(state)
+		}
+
+		select {
+		case <-c.appDone:
+			// While waiting for collector start, an error was found. Most likely
+			// an invalid custom collector configuration file.
+			return c.startError(c.svc.GetState())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ConfigResolveDuration returns how long resolving the configuration took while the collector started.
+// It is only meaningful once Start has returned.
+func (c *Collector) ConfigResolveDuration() time.Duration {
+	return c.configProvider.resolved
+}
+
+// Provenance returns what the collector runs and where its configuration came from. It is only meaningful once
+// Start has returned.
+func (c *Collector) Provenance() audit.Provenance {
+	return audit.Provenance{
+		Version:        Version,
+		GitHash:        GitHash,
+		ConfigURI:      c.configURI,
+		ConfigChecksum: c.configChecksum,
+		Components:     audit.Components(c.configProvider.cfg),
+	}
+}
+
+func (c *Collector) startError(state service.State) error {
+	if c.appErr != nil {
+		return c.appErr
+	}
+	return fmt.Errorf("unable to start, otelcol state is %d", state)
+}
+
+func (c *Collector) Stop() error {
+	if c.appDone == nil {
+		// Start failed before the collector was running.
+		return nil
+	}
+	if !c.stopped {
+		c.stopped = true
+		c.svc.Shutdown()
+	}
+	<-c.appDone
+	return c.appErr
+}
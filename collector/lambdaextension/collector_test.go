@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdaextension
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/converter/expandconverter"
+	"go.opentelemetry.io/collector/confmap/provider/envprovider"
+	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
+	"go.opentelemetry.io/collector/confmap/provider/httpprovider"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestParseSchemes(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		val      string
+		expected map[string]bool
+	}{
+		{name: "unrestricted", val: "", expected: nil},
+		{name: "one scheme", val: "s3", expected: map[string]bool{"s3": true}},
+		{name: "spaces", val: " file , s3 ", expected: map[string]bool{"file": true, "s3": true}},
+		{name: "empty entries", val: "file,,s3,", expected: map[string]bool{"file": true, "s3": true}},
+		{name: "only empty entries", val: " , ", expected: map[string]bool{}},
+		{name: "unknown scheme", val: "file,ftp", expected: map[string]bool{"file": true, "ftp": true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseSchemes(tc.val))
+		})
+	}
+}
+
+func TestFilterProviders(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	providers := []confmap.Provider{fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New()}
+
+	filtered := filterProviders(zap.New(core), providers, parseSchemes("file,s3,ftp"))
+	assert.Len(t, filtered, 1)
+	assert.Contains(t, filtered, "file")
+	assert.Equal(t, 2, logs.FilterMessage("Unknown config URI scheme allowed").Len(), "no provider for s3 and ftp here")
+
+	assert.Len(t, filterProviders(zap.NewNop(), providers, nil), len(providers))
+}
+
+func TestFilterProvidersRejectsOtherSchemes(t *testing.T) {
+	dir := t.TempDir()
+	embedded := filepath.Join(dir, "embedded.yaml")
+	require.NoError(t, os.WriteFile(embedded, []byte("exporters: ${http://config.example.com/exporters.yaml}\n"), 0600))
+	expanded := filepath.Join(dir, "expanded.yaml")
+	require.NoError(t, os.WriteFile(expanded, []byte("endpoint: ${ENDPOINT}\n"), 0600))
+	t.Setenv("ENDPOINT", "localhost:4317")
+
+	providers := []confmap.Provider{fileprovider.New(), envprovider.New(), yamlprovider.New(), httpprovider.New()}
+	resolve := func(uri string) (*confmap.Conf, error) {
+		resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+			URIs:       []string{uri},
+			Providers:  filterProviders(zap.NewNop(), providers, parseSchemes("file,s3")),
+			Converters: []confmap.Converter{expandconverter.New()},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resolver.Resolve(context.Background())
+	}
+
+	_, err := resolve("http://config.example.com/config.yaml")
+	assert.Error(t, err, "config URI")
+	_, err = resolve("file:" + embedded)
+	assert.ErrorContains(t, err, `scheme "http" is not supported`, "URI embedded in the config")
+
+	conf, err := resolve("file:" + expanded)
+	require.NoError(t, err, "environment variables are expanded without the env scheme")
+	assert.Equal(t, "localhost:4317", conf.Get("endpoint"))
+}
@@ -0,0 +1,336 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdaextension // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdaextension"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/audit"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/extensionapi"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/footprint"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/lifecycle"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/logging"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/secrets"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/telemetryapi"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/internal/tuning"
+	"github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	extensionName = filepath.Base(os.Args[0]) // extension name has to match the filename
+)
+
+// Run registers the extension with the Lambda Extensions API, starts a collector built from the
+// factories returned by components, and drives it through the lifecycle of the execution environment
+// until it shuts down. It is meant to be the main function of a distribution of the extension.
+func Run(components func() (component.Factories, error)) {
+	sampler := logging.NewSampler()
+	logger := initLogger(sampler)
+	logger.Info("Launching OpenTelemetry Lambda extension", zap.String("version", Version), zap.Bool("fips140", fipsEnabled()))
+
+	if limit, err := tuning.SetMemoryLimit(); err != nil {
+		logger.Warn("Cannot set the memory limit", zap.Error(err))
+	} else if limit > 0 {
+		logger.Info("Set the memory limit", zap.Int64("bytes", limit))
+	}
+	if procs, err := tuning.SetMaxProcs(); err != nil {
+		logger.Warn("Cannot set GOMAXPROCS", zap.Error(err))
+	} else if procs > 0 {
+		logger.Info("Set GOMAXPROCS", zap.Int("procs", procs))
+	}
+
+	ctx, lm := newLifecycleManager(context.Background(), logger, sampler, components)
+
+	// Will block until shutdown event is received or cancelled via the context.
+	lm.processEvents(ctx)
+}
+
+type lifecycleManager struct {
+	logger          *zap.Logger
+	collector       *Collector
+	extensionClient *extensionapi.Client
+	listener        *telemetryapi.Listener
+	notifier        *lifecycle.Notifier
+	sampler         *logging.Sampler
+	leaks           *lifecycle.LeakDetector
+	flush           *lifecycle.FlushServer
+	started         time.Time
+	invocations     int
+	// initDone is set once listeners have been told when the runtime finished initializing.
+	initDone bool
+}
+
+func newLifecycleManager(ctx context.Context, logger *zap.Logger, sampler *logging.Sampler, components func() (component.Factories, error)) (context.Context, *lifecycleManager) {
+	started := time.Now()
+	breakdown := lifecycle.NewInitBreakdown(started)
+	ctx, cancel := context.WithCancel(ctx)
+
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		s := <-sigs
+		cancel()
+		logger.Info("received signal", zap.String("signal", s.String()))
+	}()
+
+	extensionClient := extensionapi.NewClient(logger, os.Getenv("AWS_LAMBDA_RUNTIME_API"))
+	done := breakdown.Track(lifecycle.PhaseRegister)
+	res, err := extensionClient.Register(ctx, extensionName)
+	done()
+	if err != nil {
+		logger.Fatal("Cannot register extension", zap.Error(err))
+	}
+
+	if err = view.Register(telemetryapi.MetricViews()...); err != nil {
+		logger.Warn("Cannot register Telemetry API metric views", zap.Error(err))
+	}
+	if err = view.Register(lifecycle.InitMetricViews()...); err != nil {
+		logger.Warn("Cannot register init metric views", zap.Error(err))
+	}
+	if err = view.Register(footprint.MetricViews()...); err != nil {
+		logger.Warn("Cannot register component footprint metric views", zap.Error(err))
+	}
+	if err = view.Register(audit.MetricViews()...); err != nil {
+		logger.Warn("Cannot register config provenance metric views", zap.Error(err))
+	}
+
+	notifier := lifecycle.NewNotifier()
+	lambdalifecycle.SetNotifier(notifier)
+
+	var secretFiles []string
+	if spec := os.Getenv(secrets.EnvSecretFiles); spec != "" {
+		done = breakdown.Track(lifecycle.PhaseSecrets)
+		secretFiles = writeSecretFiles(ctx, logger, spec)
+		done()
+	}
+
+	done = breakdown.Track(lifecycle.PhaseFactories)
+	factories, err := components()
+	done()
+	if err != nil {
+		logger.Fatal("Cannot build the collector components", zap.Error(err))
+	}
+	factories, report := footprint.Wrap(factories)
+	collector := NewCollector(logger, factories, sampler.Option())
+
+	// The collector, and the network listeners of its receivers, start while the extension subscribes to
+	// the Telemetry API: both must be done before the extension is ready for the first invocation.
+	collectorStarted := make(chan error, 1)
+	go func() {
+		collectorStart := time.Now()
+		err := collector.Start(ctx)
+		// The configuration is resolved by the collector as it starts: report it as its own phase.
+		resolved := collector.ConfigResolveDuration()
+		breakdown.Record(lifecycle.PhaseConfigResolve, resolved)
+		breakdown.Record(lifecycle.PhaseServiceStart, time.Since(collectorStart)-resolved)
+		collectorStarted <- err
+	}()
+
+	listener := telemetryapi.NewListener(logger)
+	done = breakdown.Track(lifecycle.PhaseTelemetryListener)
+	addr, err := listener.Start()
+	done()
+	if err != nil {
+		logger.Fatal("Cannot start Telemetry API Listener", zap.Error(err))
+	}
+
+	telemetryClient := telemetryapi.NewClient(logger)
+	done = breakdown.Track(lifecycle.PhaseTelemetrySubscribe)
+	_, err = telemetryClient.Subscribe(ctx, res.ExtensionID, addr)
+	done()
+	if err != nil {
+		logger.Fatal("Cannot register Telemetry API client", zap.Error(err))
+	}
+
+	err = <-collectorStarted
+	// The components loaded the files as they started: the function must not be able to read them from now on.
+	if err := secrets.RemoveFiles(secretFiles); err != nil {
+		logger.Error("Cannot remove secret files", zap.Error(err))
+	}
+	if err != nil {
+		// Fatal exits, so Lambda is told about the failure first.
+		if _, initErr := extensionClient.InitError(ctx, fmt.Sprintf("failed to start the collector: %v", err)); initErr != nil {
+			logger.Error("Cannot report the init error", zap.Error(initErr))
+		}
+		logger.Fatal("Failed to start the extension", zap.Error(err))
+	}
+	breakdown.Done(ctx)
+	logger.Info("Init breakdown", breakdown.Fields()...)
+	checkInitBudget(ctx, logger, breakdown)
+	report.Record(ctx)
+	logger.Info("Component footprint", report.Fields()...)
+	provenance := collector.Provenance()
+	provenance.Record(ctx)
+	logger.Info("Config provenance", provenance.Fields()...)
+
+	var leaks *lifecycle.LeakDetector
+	if os.Getenv(lifecycle.EnvLeakDetection) == "true" {
+		leaks = lifecycle.NewLeakDetector()
+	}
+
+	var flush *lifecycle.FlushServer
+	if addr := os.Getenv(lifecycle.EnvFlushEndpoint); addr != "" {
+		flush = lifecycle.NewFlushServer(logger, notifier)
+		if err = flush.Start(addr); err != nil {
+			logger.Warn("Cannot serve the flush endpoint", zap.String("address", addr), zap.Error(err))
+			flush = nil
+		}
+	}
+
+	return ctx, &lifecycleManager{
+		logger:          logger.Named("lifecycleManager"),
+		collector:       collector,
+		extensionClient: extensionClient,
+		listener:        listener,
+		notifier:        notifier,
+		sampler:         sampler,
+		leaks:           leaks,
+		flush:           flush,
+		started:         started,
+	}
+}
+
+// writeSecretFiles writes the secrets listed in spec to their files, which the configuration of the collector
+// may refer to, e.g. as the certificate and key of a receiver, and returns their paths.
+func writeSecretFiles(ctx context.Context, logger *zap.Logger, spec string) []string {
+	getter, err := secrets.NewSecretsManager()
+	if err != nil {
+		logger.Fatal("Cannot create the Secrets Manager client", zap.Error(err))
+	}
+	written, err := secrets.WriteFiles(ctx, getter, spec)
+	if err != nil {
+		if err := secrets.RemoveFiles(written); err != nil {
+			logger.Error("Cannot remove secret files", zap.Error(err))
+		}
+		logger.Fatal("Cannot write secrets to files", zap.Error(err))
+	}
+	return written
+}
+
+// checkInitBudget warns when the initialization took longer than the budget set in the environment, if any.
+func checkInitBudget(ctx context.Context, logger *zap.Logger, breakdown *lifecycle.InitBreakdown) {
+	val, ok := os.LookupEnv(lifecycle.EnvInitBudget)
+	if !ok {
+		return
+	}
+	budget, err := time.ParseDuration(val)
+	if err != nil {
+		logger.Warn("Cannot parse the init budget", zap.String("budget", val), zap.Error(err))
+		return
+	}
+	if breakdown.CheckBudget(ctx, budget) {
+		logger.Warn("Init budget exceeded", zap.Duration("budget", budget), zap.Duration("total", breakdown.Total()))
+	}
+}
+
+func (lm *lifecycleManager) processEvents(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			lm.logger.Debug("Waiting for event...")
+			res, err := lm.extensionClient.NextEvent(ctx)
+			if err != nil {
+				// Retries are exhausted: flush what has been collected before giving up on the environment.
+				lm.logger.Warn("error waiting for extension event", zap.Error(err))
+				if stopErr := lm.stop(); stopErr != nil {
+					lm.logger.Warn("error stopping collector", zap.Error(stopErr))
+				}
+				lm.extensionClient.ExitError(ctx, fmt.Sprintf("error waiting for extension event: %v", err))
+				return
+			}
+
+			lm.logger.Debug("Received ", zap.Any("event :", res))
+			eventCtx, cancel := context.WithDeadline(ctx, time.UnixMilli(res.DeadlineMs))
+			// Exit if we receive a SHUTDOWN event
+			if res.EventType == extensionapi.Shutdown {
+				lm.logger.Info("Received SHUTDOWN event")
+				lm.notifier.Shutdown(eventCtx, res.ShutdownReason)
+				summary := lifecycle.Summarize(res.ShutdownReason, lm.invocations, lm.started)
+				lm.logger.Info("Environment summary", append(summary.Fields(), zap.Int64("suppressedLogs", lm.sampler.Suppressed()))...)
+				cancel()
+				if err = lm.stop(); err != nil {
+					lm.extensionClient.ExitError(ctx, fmt.Sprintf("error stopping collector: %v", err))
+				}
+				return
+			}
+
+			lm.invocations++
+			lm.notifier.Invoked(eventCtx, lambdalifecycle.InvokeEvent{
+				RequestID:          res.RequestID,
+				InvokedFunctionArn: res.InvokedFunctionArn,
+				Deadline:           time.UnixMilli(res.DeadlineMs),
+				TraceHeader:        res.Tracing.Value,
+			})
+
+			err = lm.listener.Wait(ctx, res.RequestID)
+			if err != nil {
+				lm.logger.Error("problem waiting for platform.runtimeDone event", zap.Error(err), zap.String("requestID", res.RequestID))
+			}
+			if end, ok := lm.listener.InitEnd(); ok && !lm.initDone {
+				lm.initDone = true
+				lm.notifier.InitDone(eventCtx, end)
+			}
+			// Listeners are told the invocation is over even if its end could not be observed, so they don't keep it
+			// pending until the environment shuts down.
+			lm.notifier.RuntimeDone(eventCtx, res.RequestID)
+			if lm.leaks != nil {
+				for _, leak := range lm.leaks.Snapshot() {
+					lm.logger.Warn("Possible leak: grew after every invocation", leak.Fields()...)
+				}
+			}
+			if n, ok := lm.sampler.Report(time.Now()); ok {
+				lm.logger.Warn("Suppressed repeated log entries", zap.Int64("count", n))
+			}
+			cancel()
+		}
+	}
+}
+
+// stop shuts the Telemetry API listener and the flush endpoint down and stops the collector, flushing its pipelines.
+func (lm *lifecycleManager) stop() error {
+	lm.listener.Shutdown()
+	lm.flush.Shutdown()
+	return lm.collector.Stop()
+}
+
+func initLogger(sampler *logging.Sampler) *zap.Logger {
+	lvl := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+	envLvl := os.Getenv("OPENTELEMETRY_EXTENSION_LOG_LEVEL")
+	userLvl, err := zap.ParseAtomicLevel(envLvl)
+	if err == nil {
+		lvl = userLvl
+	}
+
+	l := zap.New(sampler.Wrap(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), os.Stdout, lvl)))
+
+	if err != nil && envLvl != "" {
+		l.Warn("unable to parse log level from environment", zap.Error(err))
+	}
+
+	return l
+}
@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lambdalifecycle lets collector components running inside the Lambda extension
+// observe the lifecycle of the execution environment.
+package lambdalifecycle // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+
+import (
+	"context"
+	"time"
+)
+
+// InvokeEvent describes an invocation as announced by the Extensions API.
+type InvokeEvent struct {
+	RequestID          string
+	InvokedFunctionArn string
+	// Deadline is the time by which the invocation times out.
+	Deadline time.Time
+	// TraceHeader is the X-Ray tracing header of the invocation, if any.
+	TraceHeader string
+}
+
+// Listener is implemented by components that want to be told about lifecycle events.
+// Callbacks are invoked synchronously, one listener after the other; the context carries
+// the deadline of the invocation or of the shutdown, and listeners must return before it expires.
+//
+// Components register when they start, which the collector does from exporters to receivers.
+// OnInvoke is called in registration order, OnRuntimeDone and OnShutdown in the reverse order,
+// so that data a receiver emits when an invocation ends flows through the processors finishing
+// it, like when the collector shuts down.
+type Listener interface {
+	// OnInvoke is called when the function is invoked, before the runtime processes the event.
+	OnInvoke(ctx context.Context, event InvokeEvent)
+	// OnRuntimeDone is called once the runtime has returned its response for the invocation.
+	OnRuntimeDone(ctx context.Context, requestID string)
+	// OnShutdown is called when the environment is about to be shut down, before the collector stops.
+	OnShutdown(ctx context.Context, reason string)
+}
+
+// InitListener is implemented by listeners that also want to know when the runtime finished initializing.
+type InitListener interface {
+	Listener
+	// OnInitDone is called at most once, with the time the Telemetry API reported the runtime initialized, before
+	// OnRuntimeDone of the invocation during which the report was received.
+	OnInitDone(ctx context.Context, end time.Time)
+}
+
+// FlushListener is implemented by listeners holding telemetry that function code may ask to be sent right away.
+type FlushListener interface {
+	Listener
+	// OnFlush sends the telemetry held by the listener, within the deadline of ctx if any.
+	OnFlush(ctx context.Context) error
+}
+
+// Notifier delivers lifecycle events to registered listeners.
+type Notifier interface {
+	AddListener(listener Listener)
+	RemoveListener(listener Listener)
+}
+
+var notifier Notifier
+
+// SetNotifier is called by the extension on startup, before any component is created.
+func SetNotifier(n Notifier) {
+	notifier = n
+}
+
+// GetNotifier returns the notifier of the running extension, or nil when the component
+// is not running inside the Lambda extension.
+func GetNotifier() Notifier {
+	return notifier
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdalifecycle // import "github.com/open-telemetry/opentelemetry-lambda/collector/lambdalifecycle"
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Ticker is a time.Ticker that only ticks while the environment is processing an invocation. Timers keep
+// running while the environment is frozen between invocations, so periodic work would otherwise be due as
+// soon as it thaws, competing with the invocation. A Ticker is stopped when the runtime is done with an
+// invocation, and restarted a full period after the next one starts, without ticks left over from before
+// the freeze. Outside of the Lambda extension, it ticks like a time.Ticker.
+type Ticker struct {
+	// C is the channel on which the ticks are delivered.
+	C <-chan time.Time
+
+	mu       sync.Mutex
+	ticker   *time.Ticker
+	d        time.Duration
+	notifier Notifier
+	stopped  bool
+}
+
+var _ Listener = (*Ticker)(nil)
+
+// NewTicker returns a Ticker ticking every d while invocations are processed. It panics if d is not positive,
+// like time.NewTicker. It is typically created when a component starts, and must be stopped when it shuts down.
+func NewTicker(d time.Duration) *Ticker {
+	t := &Ticker{ticker: time.NewTicker(d), d: d, notifier: GetNotifier()}
+	t.C = t.ticker.C
+	if t.notifier != nil {
+		t.notifier.AddListener(t)
+	}
+	return t
+}
+
+// Stop turns the ticker off. No more ticks are delivered after it returns.
+func (t *Ticker) Stop() {
+	t.mu.Lock()
+	t.stopped = true
+	t.stop()
+	t.mu.Unlock()
+	if t.notifier != nil {
+		t.notifier.RemoveListener(t)
+	}
+}
+
+// OnInvoke restarts the ticker, dropping a tick that was due while the environment was frozen.
+func (t *Ticker) OnInvoke(context.Context, InvokeEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stopped {
+		return
+	}
+	t.stop()
+	t.ticker.Reset(t.d)
+}
+
+// OnRuntimeDone pauses the ticker until the next invocation, as the environment is about to be frozen.
+func (t *Ticker) OnRuntimeDone(context.Context, string) {
+	t.mu.Lock()
+	t.stop()
+	t.mu.Unlock()
+}
+
+// OnShutdown does nothing: the ticker keeps its state, paused if the runtime is done with the last invocation.
+func (t *Ticker) OnShutdown(context.Context, string) {}
+
+// stop stops the underlying ticker and drops the tick it may have buffered in C. Stopping a ticker doesn't drain
+// its channel under the timer semantics before Go 1.23, which toolchains up to Go 1.26 keep for modules declaring
+// an older version.
+func (t *Ticker) stop() {
+	t.ticker.Stop()
+	select {
+	case <-t.ticker.C:
+	default:
+	}
+}
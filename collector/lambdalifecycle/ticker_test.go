@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lambdalifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+type notifierFunc struct {
+	listeners map[Listener]struct{}
+}
+
+func (n *notifierFunc) AddListener(l Listener)    { n.listeners[l] = struct{}{} }
+func (n *notifierFunc) RemoveListener(l Listener) { delete(n.listeners, l) }
+
+func received(c <-chan time.Time, within time.Duration) bool {
+	select {
+	case <-c:
+		return true
+	case <-time.After(within):
+		return false
+	}
+}
+
+func TestTickerPausedBetweenInvocations(t *testing.T) {
+	n := &notifierFunc{listeners: map[Listener]struct{}{}}
+	SetNotifier(n)
+	defer SetNotifier(nil)
+
+	ticker := NewTicker(10 * time.Millisecond)
+	if _, ok := n.listeners[ticker]; !ok {
+		t.Fatal("ticker not registered with the notifier")
+	}
+	if !received(ticker.C, time.Second) {
+		t.Fatal("no tick before the first invocation ends")
+	}
+
+	ticker.OnRuntimeDone(context.Background(), "1")
+	time.Sleep(30 * time.Millisecond)
+	if received(ticker.C, 30*time.Millisecond) {
+		t.Fatal("tick while the environment is frozen")
+	}
+
+	ticker.OnInvoke(context.Background(), InvokeEvent{})
+	if !received(ticker.C, time.Second) {
+		t.Fatal("no tick once invoked again")
+	}
+
+	ticker.Stop()
+	if _, ok := n.listeners[ticker]; ok {
+		t.Fatal("ticker still registered once stopped")
+	}
+	ticker.OnInvoke(context.Background(), InvokeEvent{})
+	if received(ticker.C, 30*time.Millisecond) {
+		t.Fatal("tick once stopped")
+	}
+}
+
+func TestTickerStopDropsPendingTick(t *testing.T) {
+	ticker := NewTicker(time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	ticker.Stop()
+	if received(ticker.C, 30*time.Millisecond) {
+		t.Fatal("tick once stopped")
+	}
+}
+
+func TestTickerOutsideExtension(t *testing.T) {
+	ticker := NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	if !received(ticker.C, time.Second) {
+		t.Fatal("no tick")
+	}
+}